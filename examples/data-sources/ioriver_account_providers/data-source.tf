@@ -0,0 +1,14 @@
+// all account providers of the account
+data "ioriver_account_providers" "all" {
+}
+
+// the Fastly account provider named "production"
+data "ioriver_account_providers" "fastly" {
+  cdn          = "fastly"
+  display_name = "production"
+}
+
+resource "ioriver_service_provider" "fastly" {
+  service          = ioriver_service.service.id
+  account_provider = data.ioriver_account_providers.fastly.ids[0]
+}
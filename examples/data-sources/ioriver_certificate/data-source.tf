@@ -0,0 +1,20 @@
+// lookup by CN, e.g. a certificate managed in another workspace
+data "ioriver_certificate" "wildcard" {
+  cn = "*.example.com"
+}
+
+resource "ioriver_service" "service" {
+  name        = "my-service"
+  certificate = data.ioriver_certificate.wildcard.id
+
+  config = {
+  }
+}
+
+// fail the plan when the certificate expires within 30 days
+check "certificate_expiry" {
+  assert {
+    condition     = timecmp(data.ioriver_certificate.wildcard.not_valid_after, timeadd(plantimestamp(), "720h")) > 0
+    error_message = "Certificate ${data.ioriver_certificate.wildcard.name} expires on ${data.ioriver_certificate.wildcard.not_valid_after}"
+  }
+}
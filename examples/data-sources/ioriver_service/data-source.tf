@@ -0,0 +1,13 @@
+// lookup by id
+data "ioriver_service" "by_id" {
+  id = "c6f3c9a5-2f4c-4d47-a1f1-0f1e2d3c4b5a"
+}
+
+// lookup by name, e.g. for pointing DNS records at the service
+data "ioriver_service" "web" {
+  name = "web"
+}
+
+output "web_cname" {
+  value = data.ioriver_service.web.cname
+}
@@ -0,0 +1,14 @@
+// the live config of the service and the config it replaced
+data "ioriver_service_config_versions" "history" {
+  service        = ioriver_service.service.id
+  limit          = 2
+  include_config = true
+}
+
+output "live_version" {
+  value = data.ioriver_service_config_versions.history.current_version
+}
+
+output "previous_domains" {
+  value = try(data.ioriver_service_config_versions.history.versions[1].config.domains, [])
+}
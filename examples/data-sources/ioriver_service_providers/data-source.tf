@@ -0,0 +1,20 @@
+data "ioriver_service_providers" "all" {
+  service = ioriver_service.service.id
+}
+
+// split the traffic evenly between all active service providers
+resource "ioriver_traffic_policy" "default_traffic_policy" {
+  service    = ioriver_service.service.id
+  type       = "Static"
+  failover   = true
+  is_default = true
+  providers = [
+    for sp in data.ioriver_service_providers.all.service_providers : {
+      service_provider = sp.id
+    } if sp.status == "Active" && !sp.is_failed
+  ]
+  geos = []
+
+  health_monitors      = []
+  performance_monitors = []
+}
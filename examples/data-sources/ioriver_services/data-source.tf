@@ -0,0 +1,18 @@
+// all services of the account
+data "ioriver_services" "all" {
+}
+
+// production services using a specific certificate, including their config
+data "ioriver_services" "production" {
+  name_prefix    = "prod-"
+  certificate    = ioriver_certificate.wildcard.id
+  include_config = true
+}
+
+resource "ioriver_health_monitor" "monitors" {
+  for_each = toset(data.ioriver_services.production.ids)
+
+  service = each.value
+  name    = "availability"
+  url     = "https://domain.example.com/ping"
+}
@@ -3,6 +3,9 @@ resource "ioriver_account_provider" "fastly" {
   credentials = {
     fastly = "ulMy_iABCh-6fzo6cvRblzEJ1auAlvu"
   }
+
+  // credentials are write-only, increment to push rotated credentials
+  credentials_version = 1
 }
 
 // example 2 - Akamai provider
@@ -15,4 +18,28 @@ resource "ioriver_account_provider" "akamai" {
       client_secret = "jb10neLvAfXiAFkjygUHbMfWyusNlTyRQ0rL4K8ugtQ="
     }
   }
-}
\ No newline at end of file
+
+  // check the credentials with Akamai on apply
+  verify_on_apply = true
+}
+
+// example 3 - Azure CDN provider, using a service principal
+resource "ioriver_account_provider" "azure" {
+  credentials = {
+    azure_cdn = {
+      tenant_id       = "72f988bf-86f1-41af-91ab-2d7cd011db47"
+      client_id       = "0f8fad5b-d9cb-469f-a165-70867728950e"
+      client_secret   = var.azure_client_secret
+      subscription_id = "7d5f5e3a-2b1c-4e8f-9a6d-3c2b1a0f9e8d"
+    }
+  }
+  credentials_version = 1
+}
+
+// example 4 - GCP Cloud CDN provider, using a service account key
+resource "ioriver_account_provider" "gcp" {
+  credentials = {
+    gcp_cloud_cdn = file("service-account.json")
+  }
+  credentials_version = 1
+}
@@ -4,10 +4,37 @@ resource "ioriver_certificate" "self_managed_cert" {
   certificate       = file("certificate.crt")
   private_key       = file("private.key")
   certificate_chain = file("ca_bundle.crt")
+
+  // the content is write-only, increment to upload it again after changing
+  // only the private key or chain
+  content_version = 1
+
+  // plans warn when the certificate expires within 30 days
+  renew_before_days = 30
 }
 
 resource "ioriver_certificate" "managed_cert" {
   name = "example-managed-cert"
   type = "MANAGED"
   cn   = "[\"domain.example.com\"]"
+
+  // changing the CN re-issues the certificate. The service switches to the new
+  // certificate in place before the old one is deleted.
+  lifecycle {
+    create_before_destroy = true
+  }
 }
+
+// create the DNS records required to validate the managed certificate
+resource "aws_route53_record" "managed_cert_validation" {
+  for_each = {
+    for record in ioriver_certificate.managed_cert.challenges_records : record.name => record
+  }
+
+  zone_id = aws_route53_zone.example.zone_id
+  name    = each.value.name
+  type    = each.value.type
+  records = [each.value.value]
+  ttl     = 300
+}
+
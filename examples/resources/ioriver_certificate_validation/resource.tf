@@ -0,0 +1,12 @@
+// wait until the managed certificate is issued, after its DNS challenge records
+// are created, see the ioriver_certificate example. Services which set their
+// certificate to ioriver_certificate_validation.managed_cert.id are only created
+// once the certificate is usable.
+resource "ioriver_certificate_validation" "managed_cert" {
+  certificate             = ioriver_certificate.managed_cert.id
+  validation_record_fqdns = [for record in aws_route53_record.managed_cert_validation : record.fqdn]
+
+  timeouts {
+    create = "30m"
+  }
+}
@@ -124,3 +124,38 @@ resource "ioriver_service" "multi_domain_multi_origin" {
     ]
   }
 }
+
+# ---------------------------------------------------------------------------
+# 5. Unrelated hostnames served with several certificates
+#    The first certificate is the default certificate of the service. Each
+#    domain may name the certificate covering it; plans fail when a domain or
+#    alias is not covered by any certificate of the service.
+# ---------------------------------------------------------------------------
+resource "ioriver_service" "multi_certificate" {
+  name = "multi-certificate-service"
+  certificates = [
+    ioriver_certificate.cert.id,
+    ioriver_certificate.example_org.id,
+  ]
+
+  config = {
+    origins = [
+      {
+        name          = "web-origin"
+        custom_origin = { host = "web.example.com", protocol = "https" }
+      }
+    ]
+    domains = [
+      {
+        domain   = "www.example.com"
+        mappings = [{ target_mapping = "web-origin" }]
+      },
+      {
+        domain      = "www.example.org"
+        aliases     = ["example.org"]
+        certificate = ioriver_certificate.example_org.id
+        mappings    = [{ target_mapping = "web-origin" }]
+      }
+    ]
+  }
+}
@@ -0,0 +1,36 @@
+# Roll a service back to an earlier config version, e.g. after a bad behavior change.
+# The version numbers are listed by the ioriver_service_config_versions data source.
+#
+# Setting pinned_config_version posts the config of version 12 as a new version.
+# While pinned, changes of config are not applied and plans show the difference
+# between the live config and config. Once config is fixed, remove
+# pinned_config_version to apply it again.
+
+resource "ioriver_service" "pinned" {
+  name                  = "my-service"
+  certificate           = ioriver_certificate.cert.id
+  pinned_config_version = 12
+
+  config = {
+    origins = [
+      {
+        name = "my-origin"
+        custom_origin = {
+          host     = "origin.example.com"
+          protocol = "https"
+        }
+      }
+    ]
+
+    domains = [
+      {
+        domain = "cdn.example.com"
+        mappings = [
+          {
+            target_mapping = "my-origin"
+          }
+        ]
+      }
+    ]
+  }
+}
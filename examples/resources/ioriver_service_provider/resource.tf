@@ -10,4 +10,14 @@ resource "ioriver_service_provider" "akamai" {
   service              = ioriver_service.service.id
   account_provider     = ioriver_account_provider.akamai.id
   provider_custom_data = "{\"group\":\"grp_1234\",\"cp_code\":\"cpc_5678\",\"contract_id\":\"ctr_W-ABCD123\"}"
-}
\ No newline at end of file
+}
+
+// example 3 - fail fast if the provider doesn't become active within 15 minutes
+resource "ioriver_service_provider" "fastly_ci" {
+  service          = ioriver_service.service.id
+  account_provider = ioriver_account_provider.fastly.id
+
+  timeouts {
+    create = "15m"
+  }
+}
@@ -0,0 +1,540 @@
+// Package mockapi provides an in-memory stand-in for the IO River management
+// API. It is used to run the provider acceptance tests without network access
+// or real credentials: point IORIVER_API_ENDPOINT at Server.URL and the
+// ioriver-go client talks to it like it would to manage.ioriver.io.
+//
+// Objects are kept as decoded JSON maps, so the server round-trips whatever
+// fields the client sends and only interprets the few it needs (ids, service
+// config versions, service provider status).
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type object = map[string]interface{}
+
+// collection is an ordered set of objects addressed by id.
+type collection struct {
+	items map[string]object
+	order []string
+}
+
+func newCollection() *collection {
+	return &collection{items: map[string]object{}}
+}
+
+func (c *collection) put(id string, obj object) {
+	if _, ok := c.items[id]; !ok {
+		c.order = append(c.order, id)
+	}
+	c.items[id] = obj
+}
+
+func (c *collection) remove(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+	delete(c.items, id)
+	for i, existing := range c.order {
+		if existing == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (c *collection) list() []object {
+	objs := make([]object, 0, len(c.order))
+	for _, id := range c.order {
+		objs = append(objs, c.items[id])
+	}
+	return objs
+}
+
+// Server is an httptest server emulating the IO River management API.
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// collections are keyed by their normalized path, e.g. "certificates" or
+	// "services/<id>/traffic-policies".
+	collections map[string]*collection
+	// configs holds the service config version history per service id,
+	// oldest first.
+	configs map[string][]object
+	// verifications holds the verification result reported per account
+	// provider id. Account providers without one are verified successfully.
+	verifications map[string]object
+	// certificates holds the certificates bound to each service, when set. The
+	// default certificate of a service is the certificate field of the service.
+	certificates map[string][]interface{}
+	// serviceCertificatesDisabled emulates an API without the certificates list
+	// of services
+	serviceCertificatesDisabled bool
+}
+
+// MockUser is reported as the author of the service config versions.
+const MockUser = "terraform@mock.ioriver.test"
+
+// Collections served at the account level.
+var accountCollections = []string{"services", "certificates", "account-providers"}
+
+// Collections served under /services/<id>/.
+var serviceCollections = []string{"service-providers", "traffic-policies", "health-checks", "performance-checks"}
+
+// NewServer starts a new mock API server. Callers must Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		collections:   map[string]*collection{},
+		configs:       map[string][]object{},
+		verifications: map[string]object{},
+		certificates:  map[string][]interface{}{},
+	}
+	for _, name := range accountCollections {
+		s.collections[name] = newCollection()
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Endpoint returns the value to use as IORIVER_API_ENDPOINT.
+func (s *Server) Endpoint() string {
+	return s.URL + "/api/"
+}
+
+// ------- Seeding helpers ---------
+
+// AddCertificate stores a certificate and returns its id.
+func (s *Server) AddCertificate(name string, certType string, cn string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.createCertificate(object{"name": name, "type": certType, "cn": cn})
+}
+
+// SetCertificateStatus changes the status of a certificate, e.g. to simulate its
+// issuance.
+func (s *Server) SetCertificateStatus(id string, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cert := s.collections["certificates"].items[id]
+	if cert == nil {
+		panic(fmt.Sprintf("mockapi: unknown certificate %q", id))
+	}
+	cert["status"] = status
+}
+
+// SetCertificateStatusDetails changes the status of a certificate along with the
+// reason reported for it, e.g. to simulate a failed issuance.
+func (s *Server) SetCertificateStatusDetails(id string, status string, details string) {
+	s.SetCertificateStatus(id, status)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collections["certificates"].items[id]["status_details"] = details
+}
+
+// DisableServiceCertificates makes the certificates list of services unavailable,
+// like on an API which only supports the default certificate of a service.
+func (s *Server) DisableServiceCertificates() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.serviceCertificatesDisabled = true
+}
+
+// AddAccountProvider stores an account provider and returns its id.
+func (s *Server) AddAccountProvider(provider int, displayName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := newId()
+	s.collections["account-providers"].put(id, object{"id": id, "provider": provider, "display_name": displayName})
+	return id
+}
+
+// SetAccountProviderVerification sets the result of verifying the credentials of
+// an account provider. missingPermissions are reported as missing_permissions,
+// e.g. {"field": "accessKey", "permission": "cloudfront:UpdateDistribution"}.
+func (s *Server) SetAccountProviderVerification(id string, status string, missingPermissions ...map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.collections["account-providers"].items[id] == nil {
+		panic(fmt.Sprintf("mockapi: unknown account provider %q", id))
+	}
+	if missingPermissions == nil {
+		missingPermissions = []map[string]interface{}{}
+	}
+	s.verifications[id] = object{"status": status, "missing_permissions": missingPermissions}
+}
+
+// AddService stores a service together with an initial config version and
+// returns the service id.
+func (s *Server) AddService(name string, certificate string, config map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.createService(object{"name": name, "certificate": certificate}, config)
+}
+
+// AddServiceObject stores an object under one of the per-service collections
+// (service-providers, traffic-policies, health-checks, performance-checks) and
+// returns its id. Service providers are active unless obj sets their status.
+func (s *Server) AddServiceObject(serviceId string, kind string, obj map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	col := s.collections["services/"+serviceId+"/"+kind]
+	if col == nil {
+		panic(fmt.Sprintf("mockapi: unknown service %q or collection %q", serviceId, kind))
+	}
+	return s.createServiceObject(serviceId, kind, col, obj)
+}
+
+// ------- HTTP handling ---------
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	// any token is accepted, but an empty one is rejected like by the real API
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Token "); !ok || strings.TrimSpace(token) == "" {
+		writeError(w, http.StatusUnauthorized, "Authentication credentials were not provided.")
+		return
+	}
+
+	var body object
+	if r.Body != nil && (r.Method == http.MethodPost || r.Method == http.MethodPut || r.Method == http.MethodPatch) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "JSON parse error - "+err.Error())
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := splitPath(r.URL.Path)
+	switch {
+	case len(segments) >= 3 && segments[0] == "services" && segments[2] == "service-configs":
+		s.handleServiceConfigs(w, r, segments[1], segments[3:], body)
+	case len(segments) == 3 && segments[0] == "services" && segments[2] == "certificates":
+		s.handleServiceCertificates(w, r, segments[1], body)
+	case len(segments) == 1 && segments[0] == "services" && r.Method == http.MethodPost:
+		s.handleCreateService(w, body)
+	case len(segments) == 3 && segments[0] == "account-providers" && segments[2] == "verify" && r.Method == http.MethodPost:
+		s.handleVerifyAccountProvider(w, segments[1])
+	case len(segments) == 1 && segments[0] == "certificates" && r.Method == http.MethodPost:
+		id := s.createCertificate(body)
+		writeJSON(w, http.StatusCreated, s.collections["certificates"].items[id])
+	case len(segments) == 3 && segments[0] == "services" && r.Method == http.MethodPost:
+		col := s.collections[strings.Join(segments, "/")]
+		if col == nil {
+			writeError(w, http.StatusNotFound, "Not found.")
+			return
+		}
+		id := s.createServiceObject(segments[1], segments[2], col, body)
+		writeJSON(w, http.StatusCreated, col.items[id])
+	case len(segments) == 1 || len(segments) == 3:
+		s.handleCollection(w, r, strings.Join(segments, "/"), body)
+	case len(segments) == 2 || len(segments) == 4:
+		last := len(segments) - 1
+		s.handleItem(w, r, strings.Join(segments[:last], "/"), segments[last], body)
+	default:
+		writeError(w, http.StatusNotFound, "Not found.")
+	}
+}
+
+func (s *Server) handleCollection(w http.ResponseWriter, r *http.Request, key string, body object) {
+	col := s.collections[key]
+	if col == nil {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, col.list())
+	case http.MethodPost:
+		id := newId()
+		body["id"] = id
+		col.put(id, body)
+		writeJSON(w, http.StatusCreated, body)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method \"%s\" not allowed.", r.Method))
+	}
+}
+
+func (s *Server) handleItem(w http.ResponseWriter, r *http.Request, key string, id string, body object) {
+	col := s.collections[key]
+	if col == nil {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+	existing, ok := col.items[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, existing)
+	case http.MethodPut, http.MethodPatch:
+		for k, v := range body {
+			existing[k] = v
+		}
+		existing["id"] = id
+		if key == "certificates" {
+			stripCertificateMaterial(existing)
+		}
+		writeJSON(w, http.StatusOK, existing)
+	case http.MethodDelete:
+		col.remove(id)
+		if key == "services" {
+			s.removeService(id)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method \"%s\" not allowed.", r.Method))
+	}
+}
+
+func (s *Server) handleCreateService(w http.ResponseWriter, body object) {
+	// The service and its first config version are created in one request.
+	service, _ := body["service"].(map[string]interface{})
+	if service == nil {
+		service = body
+	}
+	var config map[string]interface{}
+	if serviceConfig, ok := body["service_config"].(map[string]interface{}); ok {
+		config, _ = serviceConfig["config_json"].(map[string]interface{})
+	}
+
+	id := s.createService(service, config)
+	writeJSON(w, http.StatusCreated, s.collections["services"].items[id])
+}
+
+func (s *Server) handleVerifyAccountProvider(w http.ResponseWriter, id string) {
+	if s.collections["account-providers"].items[id] == nil {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+
+	verification := object{"status": "VALID", "missing_permissions": []interface{}{}}
+	for k, v := range s.verifications[id] {
+		verification[k] = v
+	}
+	verification["verified_at"] = time.Now().UTC().Format(time.RFC3339)
+	writeJSON(w, http.StatusOK, verification)
+}
+
+func (s *Server) handleServiceCertificates(w http.ResponseWriter, r *http.Request, serviceId string, body object) {
+	service := s.collections["services"].items[serviceId]
+	if service == nil || s.serviceCertificatesDisabled {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		certificates, _ := body["certificates"].([]interface{})
+		if len(certificates) == 0 {
+			writeError(w, http.StatusBadRequest, "certificates: This list may not be empty.")
+			return
+		}
+		s.certificates[serviceId] = certificates
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method \"%s\" not allowed.", r.Method))
+		return
+	}
+
+	certificates, ok := s.certificates[serviceId]
+	if !ok {
+		certificates = []interface{}{service["certificate"]}
+	}
+	writeJSON(w, http.StatusOK, object{"certificates": certificates})
+}
+
+func (s *Server) handleServiceConfigs(w http.ResponseWriter, r *http.Request, serviceId string, rest []string, body object) {
+	versions, ok := s.configs[serviceId]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, versions)
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		// a new version must be based on the current version
+		if parent, ok := body["parent_version"].(float64); ok && int(parent) != len(versions) {
+			writeError(w, http.StatusConflict, fmt.Sprintf("Parent version %d is not the current version %d.", int(parent), len(versions)))
+			return
+		}
+		config, _ := body["config_json"].(map[string]interface{})
+		description, _ := body["description"].(string)
+		version := s.appendConfig(serviceId, config, description)
+		writeJSON(w, http.StatusCreated, version)
+	case len(rest) == 1 && rest[0] == "current" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, versions[len(versions)-1])
+	case len(rest) == 1 && r.Method == http.MethodGet:
+		for _, version := range versions {
+			if fmt.Sprint(version["version"]) == rest[0] {
+				writeJSON(w, http.StatusOK, version)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "Not found.")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method \"%s\" not allowed.", r.Method))
+	}
+}
+
+// ------- Object lifecycle (callers hold s.mu) ---------
+
+func (s *Server) createCertificate(cert object) string {
+	id := newId()
+	cert["id"] = id
+	if _, ok := cert["status"]; !ok {
+		cert["status"] = "ISSUED"
+	}
+	if _, ok := cert["status_details"]; !ok {
+		cert["status_details"] = ""
+	}
+	if _, ok := cert["not_valid_after"]; !ok {
+		cert["not_valid_after"] = time.Now().UTC().AddDate(0, 3, 0).Format(time.RFC3339)
+	}
+	if _, ok := cert["challenges"]; !ok {
+		cert["challenges"] = ""
+	}
+	stripCertificateMaterial(cert)
+	s.collections["certificates"].put(id, cert)
+	return id
+}
+
+// stripCertificateMaterial drops the fields the real backend never returns.
+func stripCertificateMaterial(cert object) {
+	delete(cert, "certificate")
+	delete(cert, "private_key")
+	delete(cert, "certificate_chain")
+}
+
+func (s *Server) createService(service object, config map[string]interface{}) string {
+	id := newId()
+	uid := strings.ReplaceAll(newId(), "-", "")[:12]
+	service["id"] = id
+	service["service_uid"] = uid
+	service["cname"] = uid + ".ioriver.net"
+	if _, ok := service["description"]; !ok {
+		service["description"] = ""
+	}
+	s.collections["services"].put(id, service)
+	for _, kind := range serviceCollections {
+		s.collections["services/"+id+"/"+kind] = newCollection()
+	}
+
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	if _, ok := config["name"]; !ok {
+		config["name"] = service["name"]
+	}
+	config["uuid"] = newId()
+	config["service_uid"] = uid
+	s.configs[id] = nil
+	s.appendConfig(id, config, "")
+	return id
+}
+
+func (s *Server) removeService(id string) {
+	delete(s.configs, id)
+	delete(s.certificates, id)
+	for _, kind := range serviceCollections {
+		delete(s.collections, "services/"+id+"/"+kind)
+	}
+}
+
+func (s *Server) createServiceObject(serviceId string, kind string, col *collection, obj object) string {
+	id := newId()
+	obj["id"] = id
+	obj["service"] = serviceId
+	if kind == "service-providers" {
+		// Deployment is instantaneous in the mock, unless a status is seeded.
+		if _, ok := obj["status"]; !ok {
+			obj["status"] = "Active"
+			obj["status_details"] = ""
+			obj["is_failed"] = false
+		}
+		if cname, _ := obj["cname"].(string); cname == "" {
+			obj["cname"] = id[:8] + ".cdn.example.net"
+		}
+	}
+	col.put(id, obj)
+	return id
+}
+
+func (s *Server) appendConfig(serviceId string, config map[string]interface{}, description string) object {
+	versions := s.configs[serviceId]
+	parent := 0
+	if len(versions) > 0 {
+		parent = len(versions)
+		// uuid and service_uid are fixed by the backend once assigned
+		current := versions[len(versions)-1]["config_json"].(map[string]interface{})
+		config["uuid"] = current["uuid"]
+		config["service_uid"] = current["service_uid"]
+	}
+	version := object{
+		"id":             newId(),
+		"version":        len(versions) + 1,
+		"parent_version": parent,
+		"description":    description,
+		"created_by":     MockUser,
+		"config_json":    config,
+		"created":        time.Now().UTC().Format(time.RFC3339),
+	}
+	s.configs[serviceId] = append(versions, version)
+	return version
+}
+
+// ------- Helpers ---------
+
+// splitPath strips the API prefix ("/api/v1/") and returns the remaining
+// non-empty path segments.
+func splitPath(p string) []string {
+	segments := []string{}
+	for _, segment := range strings.Split(p, "/") {
+		if segment == "" {
+			continue
+		}
+		if len(segments) == 0 && (segment == "api" || segment == "v1" || segment == "v2") {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+func newId() string {
+	return uuid.New().String()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, object{"detail": detail})
+}
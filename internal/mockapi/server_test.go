@@ -0,0 +1,229 @@
+package mockapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func doRequest(t *testing.T, s *Server, method string, path string, body interface{}, out interface{}) int {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatalf("failed to encode body: %s", err)
+		}
+	}
+	req, err := http.NewRequest(method, s.Endpoint()+"v1/"+path, &payload)
+	if err != nil {
+		t.Fatalf("failed to build request: %s", err)
+	}
+	req.Header.Set("Authorization", "Token test")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode < 300 && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("failed to decode response: %s", err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServer_RequiresAuthorization(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	for _, authorization := range []string{"", "Token ", "Bearer test"} {
+		req, err := http.NewRequest(http.MethodGet, s.Endpoint()+"v1/certificates/", nil)
+		if err != nil {
+			t.Fatalf("failed to build request: %s", err)
+		}
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %s", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected 401 for Authorization %q, got %d", authorization, resp.StatusCode)
+		}
+	}
+}
+
+func TestServer_CertificateLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	var created map[string]interface{}
+	status := doRequest(t, s, http.MethodPost, "certificates/", map[string]interface{}{
+		"name":        "cert",
+		"type":        "SELF_MANAGED",
+		"private_key": "secret",
+	}, &created)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	id, _ := created["id"].(string)
+	if id == "" {
+		t.Fatal("expected created certificate to have an id")
+	}
+	if _, ok := created["private_key"]; ok {
+		t.Fatal("expected private_key to be stripped from the response")
+	}
+
+	var fetched map[string]interface{}
+	if status := doRequest(t, s, http.MethodGet, "certificates/"+id+"/", nil, &fetched); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if fetched["name"] != "cert" {
+		t.Fatalf("expected name %q, got %v", "cert", fetched["name"])
+	}
+
+	if status := doRequest(t, s, http.MethodDelete, "certificates/"+id+"/", nil, nil); status != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", status)
+	}
+	if status := doRequest(t, s, http.MethodGet, "certificates/"+id+"/", nil, nil); status != http.StatusNotFound {
+		t.Fatalf("expected 404 after delete, got %d", status)
+	}
+}
+
+func TestServer_ServiceConfigVersions(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	serviceId := s.AddService("svc", "cert-id", map[string]interface{}{"domains": []interface{}{}})
+
+	var current map[string]interface{}
+	doRequest(t, s, http.MethodGet, "services/"+serviceId+"/service-configs/current/", nil, &current)
+	if current["version"] != float64(1) {
+		t.Fatalf("expected initial version 1, got %v", current["version"])
+	}
+	config := current["config_json"].(map[string]interface{})
+	if config["uuid"] == nil || config["service_uid"] == nil {
+		t.Fatalf("expected uuid and service_uid to be assigned, got %v", config)
+	}
+
+	var updated map[string]interface{}
+	status := doRequest(t, s, http.MethodPost, "services/"+serviceId+"/service-configs/", map[string]interface{}{
+		"parent_version": 1,
+		"config_json":    map[string]interface{}{"domains": []interface{}{"a"}},
+	}, &updated)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	if updated["version"] != float64(2) || updated["parent_version"] != float64(1) {
+		t.Fatalf("unexpected version chain: %v", updated)
+	}
+	if updated["config_json"].(map[string]interface{})["uuid"] != config["uuid"] {
+		t.Fatal("expected config uuid to be preserved across versions")
+	}
+
+	// a version based on an outdated parent is rejected
+	status = doRequest(t, s, http.MethodPost, "services/"+serviceId+"/service-configs/", map[string]interface{}{
+		"parent_version": 1,
+		"config_json":    map[string]interface{}{},
+	}, nil)
+	if status != http.StatusConflict {
+		t.Fatalf("expected 409 for an outdated parent version, got %d", status)
+	}
+
+	var versions []map[string]interface{}
+	doRequest(t, s, http.MethodGet, "services/"+serviceId+"/service-configs/", nil, &versions)
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 config versions, got %d", len(versions))
+	}
+}
+
+func TestServer_ServiceProviderIsActive(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	serviceId := s.AddService("svc", "cert-id", nil)
+
+	var sp map[string]interface{}
+	status := doRequest(t, s, http.MethodPost, "services/"+serviceId+"/service-providers/", map[string]interface{}{
+		"account_provider": "ap",
+	}, &sp)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	if sp["status"] != "Active" || sp["service"] != serviceId {
+		t.Fatalf("unexpected service provider: %v", sp)
+	}
+
+	// per-service collections go away with the service
+	doRequest(t, s, http.MethodDelete, "services/"+serviceId+"/", nil, nil)
+	if status := doRequest(t, s, http.MethodGet, "services/"+serviceId+"/service-providers/", nil, nil); status != http.StatusNotFound {
+		t.Fatalf("expected 404 for deleted service, got %d", status)
+	}
+}
+
+func TestServer_ServiceCertificates(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	serviceId := s.AddService("svc", "cert-1", nil)
+
+	// the default certificate only, until the list is set
+	var certificates map[string]interface{}
+	if status := doRequest(t, s, http.MethodGet, "services/"+serviceId+"/certificates/", nil, &certificates); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if fmt.Sprint(certificates["certificates"]) != "[cert-1]" {
+		t.Fatalf("unexpected certificates: %v", certificates)
+	}
+
+	body := map[string]interface{}{"certificates": []string{"cert-1", "cert-2"}}
+	if status := doRequest(t, s, http.MethodPut, "services/"+serviceId+"/certificates/", body, nil); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	doRequest(t, s, http.MethodGet, "services/"+serviceId+"/certificates/", nil, &certificates)
+	if fmt.Sprint(certificates["certificates"]) != "[cert-1 cert-2]" {
+		t.Fatalf("unexpected certificates: %v", certificates)
+	}
+
+	empty := map[string]interface{}{"certificates": []string{}}
+	if status := doRequest(t, s, http.MethodPut, "services/"+serviceId+"/certificates/", empty, nil); status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for no certificates, got %d", status)
+	}
+
+	s.DisableServiceCertificates()
+	if status := doRequest(t, s, http.MethodGet, "services/"+serviceId+"/certificates/", nil, nil); status != http.StatusNotFound {
+		t.Fatalf("expected 404 once disabled, got %d", status)
+	}
+}
+
+func TestServer_AccountProviderVerification(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	id := s.AddAccountProvider(1, "fastly")
+
+	var verification map[string]interface{}
+	if status := doRequest(t, s, http.MethodPost, "account-providers/"+id+"/verify/", map[string]interface{}{}, &verification); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if verification["status"] != "VALID" || verification["verified_at"] == "" {
+		t.Fatalf("expected a successful verification, got %v", verification)
+	}
+
+	s.SetAccountProviderVerification(id, "INVALID", map[string]interface{}{"field": "", "permission": "global:read"})
+	doRequest(t, s, http.MethodPost, "account-providers/"+id+"/verify/", map[string]interface{}{}, &verification)
+	missing, _ := verification["missing_permissions"].([]interface{})
+	if verification["status"] != "INVALID" || len(missing) != 1 {
+		t.Fatalf("expected a failed verification, got %v", verification)
+	}
+
+	if status := doRequest(t, s, http.MethodPost, "account-providers/unknown/verify/", map[string]interface{}{}, nil); status != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", status)
+	}
+}
@@ -2,13 +2,13 @@ package provider
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -20,6 +20,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AccountProviderResource{}
 var _ resource.ResourceWithImportState = &AccountProviderResource{}
+var _ ScopedResource = &AccountProviderResource{}
+var _ resource.ResourceWithUpgradeState = &AccountProviderResource{}
 
 func NewAccountProviderResource() resource.Resource {
 	return &AccountProviderResource{}
@@ -27,7 +29,7 @@ func NewAccountProviderResource() resource.Resource {
 
 type AccountProviderResourceId = string
 type AccountProviderResource struct {
-	client *ioriver.IORiverClient
+	client *Client
 }
 
 var ProviderNames = []string{"fastly", "cloudflare", "gcp_cloud_cdn", "gcp_media_cdn", "cloudfront", "azure_cdn", "akamai", "cdnetworks"}
@@ -67,6 +69,13 @@ type AkamaiCredsModel struct {
 	BaseUrl      types.String `tfsdk:"base_url"`
 }
 
+type AzureCDNCredsModel struct {
+	TenantId       types.String `tfsdk:"tenant_id"`
+	ClientId       types.String `tfsdk:"client_id"`
+	ClientSecret   types.String `tfsdk:"client_secret"`
+	SubscriptionId types.String `tfsdk:"subscription_id"`
+}
+
 type CredentialsModel struct {
 	Fastly      types.String          `tfsdk:"fastly"`
 	Cloudflare  types.String          `tfsdk:"cloudflare"`
@@ -75,12 +84,20 @@ type CredentialsModel struct {
 	Cloudfront  *AwsCredsModel        `tfsdk:"cloudfront"`
 	CDNetworks  *CDNetworksCredsModel `tfsdk:"cdnetworks"`
 	Akamai      *AkamaiCredsModel     `tfsdk:"akamai"`
+	AzureCDN    *AzureCDNCredsModel   `tfsdk:"azure_cdn"`
 }
 
 type AccountProviderResourceModel struct {
-	Id          types.String      `tfsdk:"id"`
-	Credentials *CredentialsModel `tfsdk:"credentials"`
-	DisplayName types.String      `tfsdk:"display_name"`
+	Id                 types.String      `tfsdk:"id"`
+	Credentials        *CredentialsModel `tfsdk:"credentials"`         // WriteOnly — never stored in state, not returned by API
+	CredentialsVersion types.Int64       `tfsdk:"credentials_version"` // TF-only counter; increment to push new credentials
+	DisplayName        types.String      `tfsdk:"display_name"`
+	VerifyOnApply      types.Bool        `tfsdk:"verify_on_apply"`
+	LastVerified       types.String      `tfsdk:"last_verified"`
+	VerificationStatus types.String      `tfsdk:"verification_status"`
+
+	// CDN of the configured credentials, used when the credentials are not sent
+	cdn string
 }
 
 func (r *AccountProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -90,6 +107,7 @@ func (r *AccountProviderResource) Metadata(ctx context.Context, req resource.Met
 func (r *AccountProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "AccountProvider resource",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -99,128 +117,14 @@ func (r *AccountProviderResource) Schema(ctx context.Context, req resource.Schem
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
-			"credentials": schema.SingleNestedAttribute{
-				MarkdownDescription: "Account-Provider credentials",
-				Required:            true,
-				Attributes: map[string]schema.Attribute{
-					"fastly": schema.StringAttribute{
-						MarkdownDescription: "Fastly API access token",
-						Optional:            true,
-						Sensitive:           true,
-						Validators: []validator.String{
-							stringvalidator.ExactlyOneOf(path.Expressions{
-								path.MatchRelative().AtParent().AtName("cloudflare"),
-								path.MatchRelative().AtParent().AtName("cloudfront"),
-								path.MatchRelative().AtParent().AtName("cdnetworks"),
-								path.MatchRelative().AtParent().AtName("akamai"),
-								path.MatchRelative().AtParent().AtName("gcp_cloud_cdn"),
-								path.MatchRelative().AtParent().AtName("gcp_media_cdn"),
-							}...),
-						},
-					},
-					"cloudflare": schema.StringAttribute{
-						MarkdownDescription: "Cloudflare API access token",
-						Optional:            true,
-						Sensitive:           true,
-					},
-					"gcp_cloud_cdn": schema.StringAttribute{
-						MarkdownDescription: "GCP project ID",
-						Optional:            true,
-						Sensitive:           true,
-					},
-					"gcp_media_cdn": schema.StringAttribute{
-						MarkdownDescription: "GCP project ID",
-						Optional:            true,
-						Sensitive:           true,
-					},
-					"cloudfront": schema.SingleNestedAttribute{
-						MarkdownDescription: "Either AWS role or access-key credentials",
-						Optional:            true,
-						Attributes: map[string]schema.Attribute{
-							"access_key": schema.SingleNestedAttribute{
-								MarkdownDescription: "AWS access-key credentials",
-								Optional:            true,
-								Sensitive:           true,
-								Validators: []validator.Object{
-									objectvalidator.ExactlyOneOf(path.Expressions{
-										path.MatchRelative().AtParent().AtName("assume_role"),
-									}...),
-								},
-								Attributes: map[string]schema.Attribute{
-									"access_key": schema.StringAttribute{
-										MarkdownDescription: "AWS access-key ID",
-										Required:            true,
-									},
-									"secret_key": schema.StringAttribute{
-										MarkdownDescription: "AWS access-key secret",
-										Required:            true,
-									},
-								},
-							},
-							"assume_role": schema.SingleNestedAttribute{
-								MarkdownDescription: "AWS role credentials",
-								Optional:            true,
-								Sensitive:           true,
-								Attributes: map[string]schema.Attribute{
-									"role_arn": schema.StringAttribute{
-										MarkdownDescription: "AWS role ARN",
-										Required:            true,
-									},
-									"external_id": schema.StringAttribute{
-										MarkdownDescription: "AWS role external ID",
-										Required:            true,
-									},
-								},
-							},
-						},
-					},
-					"cdnetworks": schema.SingleNestedAttribute{
-						MarkdownDescription: "CDNetworks API credentials",
-						Optional:            true,
-						Attributes: map[string]schema.Attribute{
-							"access_key": schema.StringAttribute{
-								MarkdownDescription: "CDNetworks access key",
-								Required:            true,
-								Sensitive:           true,
-							},
-							"secret_key": schema.StringAttribute{
-								MarkdownDescription: "CDNetworks secret key",
-								Required:            true,
-								Sensitive:           true,
-							},
-							"contract_id": schema.StringAttribute{
-								MarkdownDescription: "CDNetworks contract ID",
-								Required:            true,
-								Sensitive:           true,
-							},
-						},
-					},
-					"akamai": schema.SingleNestedAttribute{
-						MarkdownDescription: "Akamai API credentials",
-						Optional:            true,
-						Attributes: map[string]schema.Attribute{
-							"client_token": schema.StringAttribute{
-								MarkdownDescription: "Akamai API client token",
-								Required:            true,
-								Sensitive:           true,
-							},
-							"client_secret": schema.StringAttribute{
-								MarkdownDescription: "Akamai API client secret",
-								Required:            true,
-								Sensitive:           true,
-							},
-							"access_token": schema.StringAttribute{
-								MarkdownDescription: "Akamai API access token",
-								Required:            true,
-								Sensitive:           true,
-							},
-							"base_url": schema.StringAttribute{
-								MarkdownDescription: "Akamai API base URL",
-								Required:            true,
-								Sensitive:           true,
-							},
-						},
-					},
+			"credentials": accountProviderCredentialsAttribute(),
+			"credentials_version": schema.Int64Attribute{
+				MarkdownDescription: "Increment this value to trigger a credentials update. " +
+					"Credentials are only sent to the backend on create and when this value changes. " +
+					"After import, set this to any value to push the credentials.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
 				},
 			},
 			"display_name": schema.StringAttribute{
@@ -229,6 +133,29 @@ func (r *AccountProviderResource) Schema(ctx context.Context, req resource.Schem
 				Computed:            true,
 				Default:             stringdefault.StaticString(""),
 			},
+			"verify_on_apply": schema.BoolAttribute{
+				MarkdownDescription: "Verify the credentials with the CDN after every create and update, " +
+					"failing the apply when permissions are missing. An account provider which fails its verification " +
+					"on create is deleted again rather than kept in state. A warning is reported when the " +
+					"verification is not available. Defaults to `false`, since the verification endpoint is not yet available on every API.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"last_verified": schema.StringAttribute{
+				MarkdownDescription: "Time of the last verification of the credentials",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					verificationResultPlanModifier{},
+				},
+			},
+			"verification_status": schema.StringAttribute{
+				MarkdownDescription: "Result of the last verification of the credentials, `VALID` or `INVALID`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					verificationResultPlanModifier{},
+				},
+			},
 		},
 	}
 }
@@ -247,15 +174,32 @@ func (r *AccountProviderResource) Create(ctx context.Context, req resource.Creat
 	var data AccountProviderResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	// credentials are WriteOnly, so they are only available in the config
+	var configData AccountProviderResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	mergeAccountProviderCredentialsFromConfig(&data, configData, nil)
+
 	newData := resourceCreate(r.client, ctx, req, resp, r, data, false)
 	if newData == nil {
 		return
 	}
 
-	// AccountProvider credential field is write-only which we need to preserve from original request
 	newAC := newData.(AccountProviderResourceModel)
-	newAC.Credentials = data.Credentials
-
+	if verifyAccountProviderOnApply(ctx, r.client, &newAC, data.cdn, &resp.Diagnostics) {
+		// an account provider kept in state with invalid credentials would be
+		// tainted, and replaced by the next apply along with every service provider
+		// using it. It is deleted instead, so the next apply creates it again.
+		if err := deleteUnverifiedAccountProvider(ctx, r.client, r, newAC); err != nil {
+			addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.Plan.Schema, "Client Error",
+				"The account provider failed verification and could not be deleted", err)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &newAC)...)
+		}
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newAC)...)
 }
 
@@ -270,10 +214,7 @@ func (r *AccountProviderResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	// AccountProvider credential field is write-only which we need to preserve from original request
 	newAC := newData.(AccountProviderResourceModel)
-	newAC.Credentials = data.Credentials
-
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newAC)...)
 }
 
@@ -284,14 +225,23 @@ func (r *AccountProviderResource) Update(ctx context.Context, req resource.Updat
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
+	// credentials are WriteOnly, so they are only available in the config, and are
+	// only sent again when credentials_version changes
+	var configData, stateData AccountProviderResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	mergeAccountProviderCredentialsFromConfig(&data, configData, &stateData)
+
 	newData := resourceUpdate(r.client, ctx, req, resp, r, data)
 	if newData == nil {
 		return
 	}
 
-	// AccountProvider credential field is write-only which we need to preserve from original request
 	updatedAC := newData.(AccountProviderResourceModel)
-	updatedAC.Credentials = data.Credentials
+	verifyAccountProviderOnApply(ctx, r.client, &updatedAC, data.cdn, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedAC)...)
 }
@@ -312,22 +262,70 @@ func (r *AccountProviderResource) ImportState(ctx context.Context, req resource.
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+func (r *AccountProviderResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	priorSchema := schemaResp.Schema
+	priorSchema.Version = 0
+	priorSchema.Attributes = map[string]schema.Attribute{
+		"id":           schemaResp.Schema.Attributes["id"],
+		"credentials":  withoutWriteOnly(schemaResp.Schema.Attributes["credentials"]),
+		"display_name": schemaResp.Schema.Attributes["display_name"],
+	}
+
+	return map[int64]resource.StateUpgrader{
+		// State upgrade from 0 to 1: credentials were stored in state, drop them
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var data AccountProviderResourceModel
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &data.Id)...)
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("display_name"), &data.DisplayName)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+// mergeAccountProviderCredentialsFromConfig copies the WriteOnly credentials from
+// the config into the plan. Credentials are only injected when credentials_version
+// changed vs state, so unchanged credentials are not re-sent to the backend on
+// every update. On create, stateData is nil → credentials are always injected.
+func mergeAccountProviderCredentialsFromConfig(planData *AccountProviderResourceModel, configData AccountProviderResourceModel, stateData *AccountProviderResourceModel) {
+	planData.Credentials = nil
+	if configData.Credentials != nil {
+		_, planData.cdn = convertCredentials(*configData.Credentials)
+	}
+	if stateData == nil || !planData.CredentialsVersion.Equal(stateData.CredentialsVersion) {
+		planData.Credentials = configData.Credentials
+	}
+}
+
 // ------- Implement base Resource API ---------
 
-func (AccountProviderResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
-	return client.CreateAccountProvider(newObj.(ioriver.AccountProvider))
+func (AccountProviderResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
+	return callCreate(ctx, client, func() (*ioriver.AccountProvider, error) {
+		return client.CreateAccountProvider(newObj.(ioriver.AccountProvider))
+	})
 }
 
-func (AccountProviderResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
-	return client.GetAccountProvider(id.(AccountProviderResourceId))
+func (AccountProviderResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
+	return callIdempotent(ctx, client, func() (*ioriver.AccountProvider, error) {
+		return client.GetAccountProvider(id.(AccountProviderResourceId))
+	})
 }
 
-func (AccountProviderResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
-	return client.UpdateAccountProvider(obj.(ioriver.AccountProvider))
+func (AccountProviderResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
+	return callIdempotent(ctx, client, func() (*ioriver.AccountProvider, error) {
+		return client.UpdateAccountProvider(obj.(ioriver.AccountProvider))
+	})
 }
 
-func (AccountProviderResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
-	return client.DeleteAccountProvider(id.(AccountProviderResourceId))
+func (AccountProviderResource) delete(ctx context.Context, client *Client, id interface{}) error {
+	return callDelete(ctx, client, func() error { return client.DeleteAccountProvider(id.(AccountProviderResourceId)) })
 }
 
 func (AccountProviderResource) getId(data interface{}) interface{} {
@@ -335,11 +333,20 @@ func (AccountProviderResource) getId(data interface{}) interface{} {
 	return d.Id.ValueString()
 }
 
+func (AccountProviderResource) lockScope(data interface{}) string {
+	return AccountProvidersLockScope
+}
+
 // Convert AccountProvider resource to AccountProvider API object
 func (AccountProviderResource) resourceToObj(ctx context.Context, data interface{}) (interface{}, error) {
 	d := data.(AccountProviderResourceModel)
-	credentials := d.Credentials
-	convertedCreds, providerName := convertCredentials(*credentials)
+
+	// credentials are omitted from the request when they are not sent
+	var convertedCreds interface{}
+	providerName := d.cdn
+	if d.Credentials != nil {
+		convertedCreds, providerName = convertCredentials(*d.Credentials)
+	}
 
 	return ioriver.AccountProvider{
 		Id:          d.Id.ValueString(),
@@ -352,10 +359,22 @@ func (AccountProviderResource) resourceToObj(ctx context.Context, data interface
 // Convert AccountProvider API object to AccountProvider resource
 func (AccountProviderResource) objToResource(ctx context.Context, obj interface{}, data interface{}) (interface{}, error) {
 	accountProvider := obj.(*ioriver.AccountProvider)
+	d := data.(AccountProviderResourceModel)
+
+	// not set after import and upgrade
+	verifyOnApply := d.VerifyOnApply
+	if verifyOnApply.IsNull() {
+		verifyOnApply = types.BoolValue(false)
+	}
 
 	return AccountProviderResourceModel{
 		Id:          types.StringValue(accountProvider.Id),
 		DisplayName: types.StringValue(accountProvider.DisplayName),
+		// not returned by the API
+		CredentialsVersion: d.CredentialsVersion,
+		VerifyOnApply:      verifyOnApply,
+		LastVerified:       d.LastVerified,
+		VerificationStatus: d.VerificationStatus,
 	}, nil
 }
 
@@ -382,47 +401,180 @@ func convertProviderName(name string) int {
 	return providerId
 }
 
-func convertCredentials(credsMap CredentialsModel) (credentials interface{}, name string) {
-	credentials = nil
-	name = ""
-
-	if !credsMap.Fastly.IsNull() {
-		credentials = credsMap.Fastly.ValueString()
-		name = "fastly"
-	} else if !credsMap.Cloudflare.IsNull() {
-		credentials = credsMap.Cloudflare.ValueString()
-		name = "cloudflare"
-	} else if !credsMap.GCPCloudCDN.IsNull() {
-		credentials = credsMap.GCPCloudCDN.ValueString()
-		name = "gcp_cloud_cdn"
-	} else if !credsMap.GCPMediaCDN.IsNull() {
-		credentials = credsMap.GCPMediaCDN.ValueString()
-		name = "gcp_media_cdn"
-	} else if credsMap.Cloudfront != nil {
-		name = "cloudfront"
-		if credsMap.Cloudfront.AccessKey != nil {
-			credentials = fmt.Sprintf("{\"accessKey\":\"%s\",\"accessSecret\":\"%s\"}",
-				credsMap.Cloudfront.AccessKey.AccessKey.ValueString(),
-				credsMap.Cloudfront.AccessKey.SecretKey.ValueString())
-		} else if credsMap.Cloudfront.AssumeRole != nil {
-			credentials = fmt.Sprintf("{\"assume_role_arn\":\"%s\",\"external_id\":\"%s\"}",
-				credsMap.Cloudfront.AssumeRole.RoleArn.ValueString(),
-				credsMap.Cloudfront.AssumeRole.ExternalId.ValueString())
-		}
-	} else if credsMap.CDNetworks != nil {
-		name = "cdnetworks"
-		credentials = fmt.Sprintf("{\"access_key\":\"%s\",\"secret_key\":\"%s\",\"contract_id\":\"%s\"}",
-			credsMap.CDNetworks.AccessKey.ValueString(),
-			credsMap.CDNetworks.SecretKey.ValueString(),
-			credsMap.CDNetworks.ContractId.ValueString())
-	} else if credsMap.Akamai != nil {
-		name = "akamai"
-		credentials = fmt.Sprintf("{\"clientToken\":\"%s\",\"clientSecret\":\"%s\",\"accessToken\":\"%s\",\"baseURL\":\"%s\"}",
-			credsMap.Akamai.ClientToken.ValueString(),
-			credsMap.Akamai.ClientSecret.ValueString(),
-			credsMap.Akamai.AccessToken.ValueString(),
-			credsMap.Akamai.BaseUrl.ValueString())
+func accountProviderCredentialsAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Account-Provider credentials (write-only, never stored in state)",
+		Required:            true,
+		WriteOnly:           true,
+		Attributes: map[string]schema.Attribute{
+			"fastly": schema.StringAttribute{
+				MarkdownDescription: "Fastly API access token",
+				Optional:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(apiTokenPattern, "must be a non-empty token without whitespace"),
+					stringvalidator.ExactlyOneOf(path.Expressions{
+						path.MatchRelative().AtParent().AtName("cloudflare"),
+						path.MatchRelative().AtParent().AtName("cloudfront"),
+						path.MatchRelative().AtParent().AtName("cdnetworks"),
+						path.MatchRelative().AtParent().AtName("akamai"),
+						path.MatchRelative().AtParent().AtName("gcp_cloud_cdn"),
+						path.MatchRelative().AtParent().AtName("gcp_media_cdn"),
+						path.MatchRelative().AtParent().AtName("azure_cdn"),
+					}...),
+				},
+			},
+			"cloudflare": schema.StringAttribute{
+				MarkdownDescription: "Cloudflare API access token",
+				Optional:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(apiTokenPattern, "must be a non-empty token without whitespace"),
+				},
+			},
+			"gcp_cloud_cdn": schema.StringAttribute{
+				MarkdownDescription: "GCP service account key in JSON format, e.g. `file(\"service-account.json\")`",
+				Optional:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					gcpServiceAccountValidator{},
+				},
+			},
+			"gcp_media_cdn": schema.StringAttribute{
+				MarkdownDescription: "GCP service account key in JSON format, e.g. `file(\"service-account.json\")`",
+				Optional:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					gcpServiceAccountValidator{},
+				},
+			},
+			"cloudfront": schema.SingleNestedAttribute{
+				MarkdownDescription: "Either AWS role or access-key credentials",
+				Optional:            true,
+				WriteOnly:           true,
+				Attributes:          cloudfrontCredsAttributes(),
+			},
+			"cdnetworks": schema.SingleNestedAttribute{
+				MarkdownDescription: "CDNetworks API credentials",
+				Optional:            true,
+				WriteOnly:           true,
+				Attributes: map[string]schema.Attribute{
+					"access_key": schema.StringAttribute{
+						MarkdownDescription: "CDNetworks access key",
+						Required:            true,
+						WriteOnly:           true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(apiTokenPattern, "must be a non-empty key without whitespace"),
+						},
+					},
+					"secret_key": schema.StringAttribute{
+						MarkdownDescription: "CDNetworks secret key",
+						Required:            true,
+						WriteOnly:           true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(apiTokenPattern, "must be a non-empty key without whitespace"),
+						},
+					},
+					"contract_id": schema.StringAttribute{
+						MarkdownDescription: "CDNetworks contract ID",
+						Required:            true,
+						WriteOnly:           true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+				},
+			},
+			"akamai": schema.SingleNestedAttribute{
+				MarkdownDescription: "Akamai API credentials",
+				Optional:            true,
+				WriteOnly:           true,
+				Attributes: map[string]schema.Attribute{
+					"client_token": schema.StringAttribute{
+						MarkdownDescription: "Akamai API client token",
+						Required:            true,
+						WriteOnly:           true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(akamaiTokenPattern, "must be an Akamai EdgeGrid token, starting with akab-"),
+						},
+					},
+					"client_secret": schema.StringAttribute{
+						MarkdownDescription: "Akamai API client secret",
+						Required:            true,
+						WriteOnly:           true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(apiTokenPattern, "must be a non-empty secret without whitespace"),
+						},
+					},
+					"access_token": schema.StringAttribute{
+						MarkdownDescription: "Akamai API access token",
+						Required:            true,
+						WriteOnly:           true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(akamaiTokenPattern, "must be an Akamai EdgeGrid token, starting with akab-"),
+						},
+					},
+					"base_url": schema.StringAttribute{
+						MarkdownDescription: "Akamai API base URL, e.g. https://akab-xxx.luna.akamaiapis.net",
+						Required:            true,
+						WriteOnly:           true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							httpsURLValidator{},
+						},
+					},
+				},
+			},
+			"azure_cdn": schema.SingleNestedAttribute{
+				MarkdownDescription: "Azure CDN service principal credentials",
+				Optional:            true,
+				WriteOnly:           true,
+				Attributes: map[string]schema.Attribute{
+					"tenant_id": schema.StringAttribute{
+						MarkdownDescription: "Microsoft Entra tenant ID",
+						Required:            true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(azureIdPattern, "must be a GUID"),
+						},
+					},
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "Service principal client (application) ID",
+						Required:            true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(azureIdPattern, "must be a GUID"),
+						},
+					},
+					"client_secret": schema.StringAttribute{
+						MarkdownDescription: "Service principal client secret",
+						Required:            true,
+						WriteOnly:           true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"subscription_id": schema.StringAttribute{
+						MarkdownDescription: "Azure subscription ID of the CDN profiles",
+						Required:            true,
+						WriteOnly:           true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(azureIdPattern, "must be a GUID"),
+						},
+					},
+				},
+			},
+		},
 	}
-
-	return credentials, name
 }
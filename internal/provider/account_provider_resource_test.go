@@ -1,10 +1,15 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	ioriver "github.com/ioriver/ioriver-go"
@@ -28,15 +33,15 @@ type TestedAccountProvider struct {
 	TestedObj[ioriver.AccountProvider]
 }
 
-func (TestedAccountProvider) Get(client *ioriver.IORiverClient, id string) (*ioriver.AccountProvider, error) {
+func (TestedAccountProvider) Get(client *Client, id string) (*ioriver.AccountProvider, error) {
 	return client.GetAccountProvider(id)
 }
 
-func (TestedAccountProvider) List(client *ioriver.IORiverClient) ([]ioriver.AccountProvider, error) {
+func (TestedAccountProvider) List(client *Client) ([]ioriver.AccountProvider, error) {
 	return client.ListAccountProviders()
 }
 
-func (TestedAccountProvider) Delete(client *ioriver.IORiverClient, object ioriver.AccountProvider, excludeIds []string) error {
+func (TestedAccountProvider) Delete(client *Client, object ioriver.AccountProvider, excludeIds []string) error {
 	idx := slices.IndexFunc(excludeIds, func(id string) bool { return id == object.Id })
 	if idx < 0 {
 		return client.DeleteAccountProvider(object.Id)
@@ -64,13 +69,15 @@ func TestAccIORiverAccountProvider_Basic(t *testing.T) {
 				Config: testAccCheckAccountProviderConfigBasic(rndName, fastlyToken),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckObjectExists[ioriver.AccountProvider](resourceName, &accountProvider, testedObj),
+					resource.TestCheckResourceAttr(resourceName, "verification_status", "VALID"),
+					resource.TestCheckResourceAttrSet(resourceName, "last_verified"),
 				),
 			},
 			{
 				ResourceName:            "ioriver_account_provider." + rndName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"credentials"}, // ignore since this field cannot be read
+				ImportStateVerifyIgnore: []string{"credentials_version", "verify_on_apply", "last_verified", "verification_status"}, // ignore since these fields are not returned by the API
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckObjectExists[ioriver.AccountProvider](resourceName, &accountProvider, testedObj),
 				),
@@ -85,5 +92,82 @@ func testAccCheckAccountProviderConfigBasic(rndName string, fastlyToken string)
 		credentials = {
 		  fastly = "%[2]s"
 		}
+		credentials_version = 1
+		verify_on_apply     = true
 	}`, rndName, fastlyToken)
 }
+
+func TestMergeAccountProviderCredentialsFromConfig(t *testing.T) {
+	config := AccountProviderResourceModel{
+		Credentials:        &CredentialsModel{Fastly: types.StringValue("token")},
+		CredentialsVersion: types.Int64Value(2),
+	}
+
+	cases := []struct {
+		name     string
+		state    *AccountProviderResourceModel
+		expected bool
+	}{
+		{"create", nil, true},
+		{"same version", &AccountProviderResourceModel{CredentialsVersion: types.Int64Value(2)}, false},
+		{"bumped version", &AccountProviderResourceModel{CredentialsVersion: types.Int64Value(1)}, true},
+		{"after import", &AccountProviderResourceModel{CredentialsVersion: types.Int64Null()}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			plan := AccountProviderResourceModel{Id: types.StringValue("ap-id"), CredentialsVersion: types.Int64Value(2)}
+			mergeAccountProviderCredentialsFromConfig(&plan, config, c.state)
+
+			obj, err := AccountProviderResource{}.resourceToObj(context.Background(), plan)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			accountProvider := obj.(ioriver.AccountProvider)
+			if sent := accountProvider.Credentials != nil; sent != c.expected {
+				t.Fatalf("expected credentials to be sent: %t, got %t", c.expected, sent)
+			}
+			// the provider is known from the config even when credentials are not sent
+			if accountProvider.Provider != ioriver.Fastly {
+				t.Fatalf("expected provider %d, got %d", ioriver.Fastly, accountProvider.Provider)
+			}
+		})
+	}
+}
+
+func TestAccountProviderResource_UpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+
+	r := &AccountProviderResource{}
+	upgrader := r.UpgradeState(ctx)[0]
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	priorState, err := tftypes.ValueFromJSONWithOpts([]byte(`{
+		"id": "ap-id",
+		"display_name": "fastly",
+		"credentials": {"fastly": "token", "cloudflare": null, "gcp_cloud_cdn": null, "gcp_media_cdn": null,
+			"cloudfront": null, "cdnetworks": null, "akamai": null}
+	}`), upgrader.PriorSchema.Type().TerraformType(ctx), tftypes.ValueFromJSONOpts{})
+	if err != nil {
+		t.Fatalf("failed to decode prior state: %s", err)
+	}
+
+	req := fwresource.UpgradeStateRequest{State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: priorState}}
+	resp := fwresource.UpgradeStateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var data AccountProviderResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("failed to read upgraded state: %v", resp.Diagnostics)
+	}
+	if data.Credentials != nil || data.Id.ValueString() != "ap-id" || data.DisplayName.ValueString() != "fastly" {
+		t.Fatalf("unexpected upgraded state: %+v", data)
+	}
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	verificationStatusValid   = "VALID"
+	verificationStatusInvalid = "INVALID"
+)
+
+// AccountProviderVerification is the result of checking the credentials of an
+// account provider against its CDN
+type AccountProviderVerification struct {
+	Status             string                             `json:"status"`
+	VerifiedAt         string                             `json:"verified_at"`
+	MissingPermissions []AccountProviderMissingPermission `json:"missing_permissions"`
+}
+
+// AccountProviderMissingPermission is a permission the credentials lack
+type AccountProviderMissingPermission struct {
+	// credentials field in the backend format, empty when not specific to a field
+	Field      string `json:"field"`
+	Permission string `json:"permission"`
+	Message    string `json:"message"`
+}
+
+// VerifyAccountProvider checks the stored credentials of an account provider
+// with its CDN
+func VerifyAccountProvider(ctx context.Context, client *Client, id string) (*AccountProviderVerification, error) {
+	return callIdempotent(ctx, client, func() (*AccountProviderVerification, error) {
+		var verification AccountProviderVerification
+		if err := apiPost(ctx, client, "account-providers/"+id+"/verify/", nil, &verification); err != nil {
+			return nil, err
+		}
+		return &verification, nil
+	})
+}
+
+// credentials attributes of the backend credentials fields, per CDN. The fields
+// are the JSON names of the credentials encoders.
+var credentialsFieldAttributes = map[string]map[string][]string{
+	"cloudfront": {
+		"accessKey":       {"access_key", "access_key"},
+		"accessSecret":    {"access_key", "secret_key"},
+		"assume_role_arn": {"assume_role", "role_arn"},
+		"external_id":     {"assume_role", "external_id"},
+	},
+	"cdnetworks": {
+		"access_key":  {"access_key"},
+		"secret_key":  {"secret_key"},
+		"contract_id": {"contract_id"},
+	},
+	"akamai": {
+		"clientToken":  {"client_token"},
+		"clientSecret": {"client_secret"},
+		"accessToken":  {"access_token"},
+		"baseURL":      {"base_url"},
+	},
+	"azure_cdn": {
+		"tenantId":       {"tenant_id"},
+		"clientId":       {"client_id"},
+		"clientSecret":   {"client_secret"},
+		"subscriptionId": {"subscription_id"},
+	},
+}
+
+// credentialsFieldPath returns the path of the credentials attribute of a backend
+// credentials field. Unknown fields are reported on the credentials of the CDN.
+func credentialsFieldPath(cdn string, field string) path.Path {
+	p := path.Root("credentials")
+	if cdn == "" {
+		return p
+	}
+	p = p.AtName(cdn)
+	for _, name := range credentialsFieldAttributes[cdn][field] {
+		p = p.AtName(name)
+	}
+	return p
+}
+
+// verifyAccountProviderOnApply verifies the credentials of an account provider
+// which was just created or updated, when verify_on_apply is set. Missing
+// permissions are reported as errors on the matching credentials attributes, and
+// a warning is reported when the API can't verify the account provider or the
+// verification call fails. Returns true only when the credentials were verified
+// and found invalid.
+func verifyAccountProviderOnApply(ctx context.Context, client *Client, data *AccountProviderResourceModel, cdn string, diags *diag.Diagnostics) bool {
+	// results of an earlier verification are kept when not verifying again
+	if data.LastVerified.IsUnknown() {
+		data.LastVerified = types.StringNull()
+	}
+	if data.VerificationStatus.IsUnknown() {
+		data.VerificationStatus = types.StringNull()
+	}
+	if !data.VerifyOnApply.ValueBool() {
+		return false
+	}
+
+	verification, err := VerifyAccountProvider(ctx, client, data.Id.ValueString())
+	if status := apiErrorStatusCode(err); status == http.StatusNotFound || status == http.StatusMethodNotAllowed {
+		diags.AddAttributeWarning(path.Root("verify_on_apply"), "Account provider verification unavailable",
+			fmt.Sprintf("The credentials were not verified, the API doesn't support verifying account providers (status %d). "+
+				"Set verify_on_apply to false to skip the verification.", status))
+		return false
+	}
+	if err != nil {
+		// a failed call says nothing about the credentials, the verification
+		// status is left unknown until the next apply
+		diags.AddAttributeWarning(path.Root("verify_on_apply"), "Account provider not verified",
+			fmt.Sprintf("Could not verify the account provider credentials, they are verified again on the next apply: %s", err))
+		return false
+	}
+	data.LastVerified = types.StringValue(verification.VerifiedAt)
+	data.VerificationStatus = types.StringValue(verification.Status)
+	verificationDiags := accountProviderVerificationDiagnostics(verification, cdn)
+	diags.Append(verificationDiags...)
+	return verificationDiags.HasError()
+}
+
+// deleteUnverifiedAccountProvider deletes an account provider which was just
+// created and failed its verification
+func deleteUnverifiedAccountProvider(ctx context.Context, client *Client, r Resource, data AccountProviderResourceModel) error {
+	tflog.Info(ctx, fmt.Sprintf("Deleting account provider %s which failed verification", data.Id.ValueString()))
+	_, err := performOperation(ctx, operationLockScope(r, data), func() (interface{}, error) {
+		return nil, r.delete(ctx, client, r.getId(data))
+	})
+	return err
+}
+
+func accountProviderVerificationDiagnostics(verification *AccountProviderVerification, cdn string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, missing := range verification.MissingPermissions {
+		detail := fmt.Sprintf("The %s credentials are missing the %s permission", cdn, missing.Permission)
+		if missing.Message != "" {
+			detail += ": " + missing.Message
+		}
+		diags.AddAttributeError(credentialsFieldPath(cdn, missing.Field), "Missing account provider permission",
+			detail+"\n\nGrant the permission, or set verify_on_apply to false to skip the verification.")
+	}
+
+	if verification.Status != verificationStatusValid && len(verification.MissingPermissions) == 0 {
+		diags.AddAttributeError(credentialsFieldPath(cdn, ""), "Invalid account provider credentials",
+			fmt.Sprintf("The %s credentials failed verification with status %s. "+
+				"Check the credentials, or set verify_on_apply to false to skip the verification.", cdn, verification.Status))
+	}
+	return diags
+}
+
+// ---------------------------------------------------------------------------
+// verificationResultPlanModifier keeps the results of the last verification
+// from state, unless the account provider is verified again by the apply.
+// ---------------------------------------------------------------------------
+
+type verificationResultPlanModifier struct{}
+
+func (m verificationResultPlanModifier) Description(_ context.Context) string {
+	return "Keeps the value from state when the account provider is not verified on apply."
+}
+
+func (m verificationResultPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m verificationResultPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// nothing to keep on create and destroy
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var verifyOnApply types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("verify_on_apply"), &verifyOnApply)...)
+	if verifyOnApply.IsUnknown() || verifyOnApply.ValueBool() {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ioriver "github.com/ioriver/ioriver-go"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
+)
+
+func TestVerifyAccountProviderOnApply(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	validId := server.AddAccountProvider(ioriver.Fastly, "fastly")
+	invalidId := server.AddAccountProvider(ioriver.Cloudfront, "cloudfront")
+	server.SetAccountProviderVerification(invalidId, verificationStatusInvalid,
+		map[string]interface{}{"field": "accessKey", "permission": "cloudfront:UpdateDistribution", "message": "access denied"},
+		map[string]interface{}{"field": "", "permission": "acm:ImportCertificate"})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	verify := func(id string, verifyOnApply bool, cdn string) (AccountProviderResourceModel, diag.Diagnostics) {
+		data := AccountProviderResourceModel{
+			Id:                 types.StringValue(id),
+			VerifyOnApply:      types.BoolValue(verifyOnApply),
+			LastVerified:       types.StringUnknown(),
+			VerificationStatus: types.StringUnknown(),
+		}
+		var diags diag.Diagnostics
+		verifyAccountProviderOnApply(ctx, client, &data, cdn, &diags)
+		return data, diags
+	}
+
+	data, diags := verify(validId, true, "fastly")
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if data.VerificationStatus.ValueString() != verificationStatusValid || data.LastVerified.ValueString() == "" {
+		t.Fatalf("expected a successful verification, got %s at %s", data.VerificationStatus, data.LastVerified)
+	}
+
+	data, diags = verify(invalidId, true, "cloudfront")
+	if data.VerificationStatus.ValueString() != verificationStatusInvalid || len(diags) != 2 {
+		t.Fatalf("expected an error per missing permission, got %s, %v", data.VerificationStatus, diags)
+	}
+	expectedPaths := []path.Path{
+		path.Root("credentials").AtName("cloudfront").AtName("access_key").AtName("access_key"),
+		path.Root("credentials").AtName("cloudfront"),
+	}
+	for i, d := range diags {
+		withPath, ok := d.(diag.DiagnosticWithPath)
+		if !ok || !withPath.Path().Equal(expectedPaths[i]) {
+			t.Fatalf("expected an error on %s, got %v", expectedPaths[i], d)
+		}
+	}
+	if !strings.Contains(diags[0].Detail(), "cloudfront:UpdateDistribution permission: access denied") {
+		t.Fatalf("unexpected error: %s", diags[0].Detail())
+	}
+
+	// not verified, no results are known
+	data, diags = verify(invalidId, false, "cloudfront")
+	if diags.HasError() || !data.VerificationStatus.IsNull() || !data.LastVerified.IsNull() {
+		t.Fatalf("expected no verification, got %s, %v", data.VerificationStatus, diags)
+	}
+
+	// the API can't verify the account provider
+	data, diags = verify("unknown", true, "fastly")
+	if diags.HasError() || diags.WarningsCount() != 1 || diags[0].Summary() != "Account provider verification unavailable" {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if !data.VerificationStatus.IsNull() || !data.LastVerified.IsNull() {
+		t.Fatalf("expected no verification results, got %s at %s", data.VerificationStatus, data.LastVerified)
+	}
+}
+
+func TestVerifyAccountProviderOnApply_CallFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `{"detail":"bad gateway"}`)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(1, time.Millisecond)
+	client.EndpointUrl = server.URL
+
+	data := AccountProviderResourceModel{
+		Id:                 types.StringValue("abc"),
+		VerifyOnApply:      types.BoolValue(true),
+		LastVerified:       types.StringUnknown(),
+		VerificationStatus: types.StringUnknown(),
+	}
+	var diags diag.Diagnostics
+	if invalid := verifyAccountProviderOnApply(context.Background(), client, &data, "fastly", &diags); invalid {
+		t.Fatal("expected a failed call not to be reported as invalid credentials")
+	}
+	if diags.HasError() || diags.WarningsCount() != 1 || diags[0].Summary() != "Account provider not verified" {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if !data.VerificationStatus.IsNull() || !data.LastVerified.IsNull() {
+		t.Fatalf("expected no verification results, got %s at %s", data.VerificationStatus, data.LastVerified)
+	}
+}
+
+func TestAccountProviderVerificationDiagnostics_Invalid(t *testing.T) {
+	diags := accountProviderVerificationDiagnostics(&AccountProviderVerification{Status: verificationStatusInvalid}, "fastly")
+	if len(diags) != 1 || diags[0].Summary() != "Invalid account provider credentials" {
+		t.Fatalf("expected a single error, got %v", diags)
+	}
+	if withPath := diags[0].(diag.DiagnosticWithPath); !withPath.Path().Equal(path.Root("credentials").AtName("fastly")) {
+		t.Fatalf("unexpected error path %s", withPath.Path())
+	}
+}
+
+func TestDeleteUnverifiedAccountProvider(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	id := server.AddAccountProvider(ioriver.Cloudfront, "cloudfront")
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	data := AccountProviderResourceModel{Id: types.StringValue(id)}
+	if err := deleteUnverifiedAccountProvider(ctx, client, &AccountProviderResource{}, data); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := client.GetAccountProvider(id); !isNotFoundError(err) {
+		t.Fatalf("expected the account provider to be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ioriver "github.com/ioriver/ioriver-go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AccountProvidersDataSource{}
+
+func NewAccountProvidersDataSource() datasource.DataSource {
+	return &AccountProvidersDataSource{}
+}
+
+type AccountProvidersDataSource struct {
+	client *Client
+}
+
+type AccountProvidersDataSourceModel struct {
+	Cdn              types.String                          `tfsdk:"cdn"`
+	DisplayName      types.String                          `tfsdk:"display_name"`
+	Ids              []types.String                        `tfsdk:"ids"`
+	AccountProviders []AccountProviderDataSourceEntryModel `tfsdk:"account_providers"`
+}
+
+type AccountProviderDataSourceEntryModel struct {
+	Id          types.String `tfsdk:"id"`
+	Cdn         types.String `tfsdk:"cdn"`
+	DisplayName types.String `tfsdk:"display_name"`
+}
+
+func (d *AccountProvidersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account_providers"
+}
+
+func (d *AccountProvidersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Account providers data source. Lists the CDN accounts connected to IO River, optionally filtered by CDN or display name.",
+
+		Attributes: map[string]schema.Attribute{
+			"cdn": schema.StringAttribute{
+				MarkdownDescription: "Only return account providers of this CDN, e.g. fastly, cloudflare",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ProviderNames...),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Only return account providers with this display name",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching account providers",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"account_providers": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching account providers, ordered by display name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "AccountProvider identifier",
+							Computed:            true,
+						},
+						"cdn": schema.StringAttribute{
+							MarkdownDescription: "The CDN of the account provider, e.g. fastly, cloudflare",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "AccountProvider display name",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure data source and retrieve API client
+func (d *AccountProvidersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	client := ConfigureDataSourceBase(ctx, req, resp)
+	if client == nil {
+		return
+	}
+	d.client = client
+}
+
+// Read AccountProviders data source
+func (d *AccountProvidersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AccountProvidersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accountProviders, err := callIdempotent(ctx, d.client, d.client.ListAccountProviders)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, nil, "Client Error", "Unable to list account providers", err)
+		return
+	}
+
+	data.Ids = []types.String{}
+	data.AccountProviders = []AccountProviderDataSourceEntryModel{}
+	for _, accountProvider := range filterAccountProviders(accountProviders, data.Cdn, data.DisplayName) {
+		data.Ids = append(data.Ids, types.StringValue(accountProvider.Id))
+		data.AccountProviders = append(data.AccountProviders, AccountProviderDataSourceEntryModel{
+			Id:          types.StringValue(accountProvider.Id),
+			Cdn:         types.StringValue(providerIdToName(accountProvider.Provider)),
+			DisplayName: types.StringValue(accountProvider.DisplayName),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterAccountProviders returns the account providers matching the given CDN and
+// display name, ordered by display name
+func filterAccountProviders(accountProviders []ioriver.AccountProvider, cdn types.String, displayName types.String) []ioriver.AccountProvider {
+	result := []ioriver.AccountProvider{}
+	for _, accountProvider := range accountProviders {
+		if !cdn.IsNull() && providerIdToName(accountProvider.Provider) != cdn.ValueString() {
+			continue
+		}
+		if !displayName.IsNull() && accountProvider.DisplayName != displayName.ValueString() {
+			continue
+		}
+		result = append(result, accountProvider)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].DisplayName != result[j].DisplayName {
+			return result[i].DisplayName < result[j].DisplayName
+		}
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+// providerIdToName converts a backend provider id to its HCL name, see convertProviderName
+func providerIdToName(providerId int) string {
+	for _, name := range ProviderNames {
+		if convertProviderName(name) == providerId {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	ioriver "github.com/ioriver/ioriver-go"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
+)
+
+var accountProvidersDataSourceType string = "data.ioriver_account_providers"
+
+func TestAccIORiverAccountProvidersDataSource_Basic(t *testing.T) {
+	fastlyToken := os.Getenv("IORIVER_TEST_FASTLY_API_TOKEN")
+	rndName := generateRandomResourceName()
+	resourceName := apResourceType + "." + rndName
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckV2(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckAccountProvidersDataSourceConfig(rndName, fastlyToken),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttrPair(accountProvidersDataSourceType+".fastly", "ids.*", resourceName, "id"),
+					resource.TestCheckResourceAttr(accountProvidersDataSourceType+".fastly", "account_providers.0.cdn", "fastly"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAccountProvidersDataSourceConfig(rndName string, fastlyToken string) string {
+	return testAccCheckAccountProviderConfigBasic(rndName, fastlyToken) + fmt.Sprintf(`
+
+	data "ioriver_account_providers" "fastly" {
+		cdn        = "fastly"
+		depends_on = [%s.%s]
+	}`, apResourceType, rndName)
+}
+
+func TestFilterAccountProviders(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	fastlyB := server.AddAccountProvider(ioriver.Fastly, "b")
+	fastlyA := server.AddAccountProvider(ioriver.Fastly, "a")
+	cloudflare := server.AddAccountProvider(ioriver.Cloudflare, "c")
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	accountProviders, err := callIdempotent(ctx, client, client.ListAccountProviders)
+	if err != nil {
+		t.Fatalf("failed to list account providers: %s", err)
+	}
+
+	cases := []struct {
+		cdn         types.String
+		displayName types.String
+		expected    []string
+	}{
+		{types.StringNull(), types.StringNull(), []string{fastlyA, fastlyB, cloudflare}},
+		{types.StringValue("fastly"), types.StringNull(), []string{fastlyA, fastlyB}},
+		{types.StringNull(), types.StringValue("b"), []string{fastlyB}},
+		{types.StringValue("cloudflare"), types.StringValue("b"), []string{}},
+		{types.StringValue("akamai"), types.StringNull(), []string{}},
+	}
+	for _, c := range cases {
+		ids := []string{}
+		for _, accountProvider := range filterAccountProviders(accountProviders, c.cdn, c.displayName) {
+			ids = append(ids, accountProvider.Id)
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(c.expected) {
+			t.Errorf("cdn %s, display name %s: expected %v, got %v", c.cdn, c.displayName, c.expected, ids)
+		}
+	}
+}
+
+func TestProviderIdToName(t *testing.T) {
+	for _, name := range ProviderNames {
+		if got := providerIdToName(convertProviderName(name)); got != name {
+			t.Errorf("expected %q, got %q", name, got)
+		}
+	}
+}
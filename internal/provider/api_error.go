@@ -0,0 +1,367 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// APIError is a failed IO River API call. Raw API calls build it from the HTTP
+// response, see apiRequest. Errors of the ioriver-go client are parsed from
+// their message, which holds the response status, e.g. "404 Not Found",
+// followed by the response body.
+type APIError struct {
+	StatusCode int
+	RequestId  string
+	// RetryAfter is the delay requested by the Retry-After header of the
+	// response, if any. It is only known for raw API calls.
+	RetryAfter time.Duration
+	// Detail is the general error message of the backend, if any
+	Detail string
+	// FieldErrors holds validation errors of specific request fields
+	FieldErrors []FieldError
+
+	// fromResponse is set when the error was built from the HTTP response
+	// rather than parsed from the message of an ioriver-go error
+	fromResponse bool
+	err          error
+}
+
+// FieldError is a validation error of a single request field. The path holds
+// the keys (and list indexes) of the field within the request body, e.g.
+// ["config_json", "domains", "0", "domain"].
+type FieldError struct {
+	Path     []string
+	Messages []string
+}
+
+func (e *APIError) Error() string {
+	return e.err.Error()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+var (
+	statusCodePattern = regexp.MustCompile(`\b([1-5][0-9]{2}) [A-Z][A-Za-z]`)
+	errorBodyPattern  = regexp.MustCompile(`(?s)body: (.*)$`)
+)
+
+// Body keys which don't describe a specific field
+var generalErrorKeys = map[string]bool{
+	"detail":           true,
+	"message":          true,
+	"error":            true,
+	"non_field_errors": true,
+}
+
+// wrapAPIError converts an error returned by the client into an *APIError when it
+// describes a failed HTTP response. Other errors are returned as is.
+func wrapAPIError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return err
+	}
+	if parsed := parseAPIError(err); parsed != nil {
+		return parsed
+	}
+	return err
+}
+
+// newResponseAPIError builds the error of a failed raw API call from its HTTP
+// response. The message has the same format as the ioriver-go errors.
+func newResponseAPIError(res *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode:   res.StatusCode,
+		RetryAfter:   parseRetryAfter(res.Header.Get("Retry-After")),
+		fromResponse: true,
+		err:          fmt.Errorf("status: %s, body: %s", res.Status, string(body)),
+	}
+	parseAPIErrorBody(apiErr, string(body))
+	return apiErr
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as an
+// HTTP date. It returns 0 when the header is missing or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
+// parseAPIError parses the message of an ioriver-go error. The status is only
+// looked for before the response body, which may contain anything.
+func parseAPIError(err error) *APIError {
+	msg := err.Error()
+	status := msg
+	if i := strings.Index(msg, "body: "); i >= 0 {
+		status = msg[:i]
+	}
+	match := statusCodePattern.FindStringSubmatch(status)
+	if match == nil {
+		return nil
+	}
+	code, _ := strconv.Atoi(match[1])
+	apiErr := &APIError{StatusCode: code, err: err}
+
+	if bodyMatch := errorBodyPattern.FindStringSubmatch(msg); bodyMatch != nil {
+		parseAPIErrorBody(apiErr, bodyMatch[1])
+	}
+	return apiErr
+}
+
+// parseAPIErrorBody sets the details of apiErr from the response body
+func parseAPIErrorBody(apiErr *APIError, rawBody string) {
+	rawBody = strings.TrimSpace(rawBody)
+	if rawBody == "" {
+		return
+	}
+
+	var body interface{}
+	if json.Unmarshal([]byte(rawBody), &body) != nil {
+		apiErr.Detail = rawBody
+		return
+	}
+
+	switch b := body.(type) {
+	case map[string]interface{}:
+		var general []string
+		for _, key := range sortedMapKeys(b) {
+			switch {
+			case key == "request_id" || key == "requestId":
+				apiErr.RequestId = fmt.Sprint(b[key])
+			case generalErrorKeys[key]:
+				general = append(general, errorMessages(b[key])...)
+			default:
+				apiErr.FieldErrors = append(apiErr.FieldErrors, collectFieldErrors([]string{key}, b[key])...)
+			}
+		}
+		apiErr.Detail = strings.Join(general, "\n")
+	case []interface{}:
+		apiErr.Detail = strings.Join(errorMessages(b), "\n")
+	case string:
+		apiErr.Detail = b
+	}
+}
+
+// collectFieldErrors flattens a (possibly nested) validation error value. Leaf
+// values are lists of messages, nested objects and lists describe sub-fields.
+func collectFieldErrors(prefix []string, value interface{}) []FieldError {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		var result []FieldError
+		for _, key := range sortedMapKeys(v) {
+			if generalErrorKeys[key] {
+				result = append(result, FieldError{Path: prefix, Messages: errorMessages(v[key])})
+				continue
+			}
+			result = append(result, collectFieldErrors(append(append([]string{}, prefix...), key), v[key])...)
+		}
+		return result
+	case []interface{}:
+		var result []FieldError
+		var messages []string
+		for i, item := range v {
+			switch item.(type) {
+			case map[string]interface{}, []interface{}:
+				result = append(result, collectFieldErrors(append(append([]string{}, prefix...), strconv.Itoa(i)), item)...)
+			default:
+				messages = append(messages, fmt.Sprint(item))
+			}
+		}
+		if len(messages) > 0 {
+			result = append([]FieldError{{Path: prefix, Messages: messages}}, result...)
+		}
+		return result
+	case nil:
+		return nil
+	default:
+		return []FieldError{{Path: prefix, Messages: []string{fmt.Sprint(v)}}}
+	}
+}
+
+func errorMessages(value interface{}) []string {
+	switch v := value.(type) {
+	case []interface{}:
+		var messages []string
+		for _, item := range v {
+			messages = append(messages, errorMessages(item)...)
+		}
+		return messages
+	case nil:
+		return nil
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+func sortedMapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// apiErrorStatusCode returns the HTTP status code of a failed API call, or 0 if
+// the error did not come from an HTTP response.
+func apiErrorStatusCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	if parsed := parseAPIError(err); parsed != nil {
+		return parsed.StatusCode
+	}
+	return 0
+}
+
+func isNotFoundError(err error) bool {
+	return apiErrorStatusCode(err) == http.StatusNotFound
+}
+
+// Keys of the request body which wrap the service config
+var configBodyKeys = map[string]bool{
+	"config_json":    true,
+	"service_config": true,
+}
+
+// Service config keys which are named differently in the resource schema
+var configKeyAttributes = map[string]string{
+	"name":            "creation_name",
+	"geo_restriction": "geo_fencing",
+	"waf":             "security",
+	"bot_management":  "security",
+}
+
+// fieldErrorPath maps the path of a request field to the matching attribute
+// path of the resource. Fields of the service config are mapped under config.*.
+// Returns false if no part of the field path exists in the schema.
+func fieldErrorPath(ctx context.Context, schema schemaTypes, fieldPath []string) (path.Path, bool) {
+	inConfig := false
+	keys := fieldPath
+	// the service is created with its config, e.g. {"service": {...}, "service_config": {"config_json": {...}}}
+	for len(keys) > 1 && (configBodyKeys[keys[0]] || keys[0] == "service") {
+		inConfig = inConfig || configBodyKeys[keys[0]]
+		keys = keys[1:]
+	}
+
+	var p path.Path
+	if inConfig {
+		p = path.Root("config")
+		if _, diags := schema.TypeAtPath(ctx, p); diags.HasError() {
+			return path.Empty(), false
+		}
+		if len(keys) > 0 {
+			if name, ok := configKeyAttributes[keys[0]]; ok {
+				keys = append([]string{name}, keys[1:]...)
+			}
+		}
+	} else {
+		if len(keys) == 0 {
+			return path.Empty(), false
+		}
+		p = path.Root(keys[0])
+		keys = keys[1:]
+		if _, diags := schema.TypeAtPath(ctx, p); diags.HasError() {
+			return path.Empty(), false
+		}
+	}
+
+	// descend as deep as the schema allows
+	for _, key := range keys {
+		var next path.Path
+		if index, err := strconv.Atoi(key); err == nil {
+			next = p.AtListIndex(index)
+		} else {
+			next = p.AtName(key)
+		}
+		if _, diags := schema.TypeAtPath(ctx, next); diags.HasError() {
+			break
+		}
+		p = next
+	}
+	return p, true
+}
+
+// schemaTypes is satisfied by the schema of a plan or state
+type schemaTypes interface {
+	TypeAtPath(context.Context, path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// addAPIErrorDiagnostics reports a failed operation. Validation errors of specific
+// fields are attached to the matching attributes, so terraform points at the
+// offending configuration line. The backend request ID is included when known.
+func addAPIErrorDiagnostics(ctx context.Context, diags *diag.Diagnostics, schema schemaTypes, summary string, prefix string, err error) {
+	var conflictErr *ConfigConflictError
+	if errors.As(err, &conflictErr) {
+		diags.AddError("Service config conflict", fmt.Sprintf("%s, %s\n\n"+
+			"The changes were not overwritten. Run terraform plan to review them, then update the configuration or apply again to replace them.",
+			prefix, conflictErr))
+		return
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		diags.AddError(summary, fmt.Sprintf("%s, unexpected error: %s", prefix, err))
+		return
+	}
+
+	suffix := ""
+	if apiErr.RequestId != "" {
+		suffix = "\n\nRequest ID: " + apiErr.RequestId
+	}
+
+	reported := false
+	var unmapped []string
+	for _, fieldErr := range apiErr.FieldErrors {
+		message := strings.Join(fieldErr.Messages, "\n")
+		if schema != nil {
+			if p, ok := fieldErrorPath(ctx, schema, fieldErr.Path); ok {
+				diags.AddAttributeError(p, summary, fmt.Sprintf("%s: %s: %s%s", prefix, strings.Join(fieldErr.Path, "."), message, suffix))
+				reported = true
+				continue
+			}
+		}
+		unmapped = append(unmapped, fmt.Sprintf("%s: %s", strings.Join(fieldErr.Path, "."), message))
+	}
+
+	if apiErr.Detail != "" || len(unmapped) > 0 || !reported {
+		details := append([]string{}, unmapped...)
+		if apiErr.Detail != "" {
+			details = append([]string{apiErr.Detail}, details...)
+		}
+		if len(details) == 0 {
+			details = []string{apiErr.Error()}
+		}
+		diags.AddError(summary, fmt.Sprintf("%s: %s (HTTP %d)%s", prefix, strings.Join(details, "\n"), apiErr.StatusCode, suffix))
+	}
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func TestApiErrorStatusCode(t *testing.T) {
+	cases := map[string]int{
+		"status: 404 Not Found, body: {}":                                          404,
+		"status: 429 Too Many Requests, body: {}":                                  429,
+		"dial tcp 127.0.0.1:1: connect: connection refused":                        0,
+		"status: 400 Bad Request, body: {\"detail\": \"503 Service Unavailable\"}": 400,
+		"unexpected response, body: 503 Service Unavailable":                       0,
+	}
+	for msg, expected := range cases {
+		if code := apiErrorStatusCode(errors.New(msg)); code != expected {
+			t.Errorf("%q: expected %d, got %d", msg, expected, code)
+		}
+	}
+	if apiErrorStatusCode(nil) != 0 {
+		t.Error("expected 0 for nil error")
+	}
+}
+
+func TestParseAPIError(t *testing.T) {
+	err := errors.New(`status: 400 Bad Request, body: {"request_id": "req-123", "non_field_errors": ["Invalid config"], "config_json": {"domains": [{}, {"domain": ["Enter a valid domain."]}]}}`)
+
+	apiErr := parseAPIError(err)
+	if apiErr == nil {
+		t.Fatal("expected error to be parsed")
+	}
+	if apiErr.StatusCode != 400 || apiErr.RequestId != "req-123" || apiErr.Detail != "Invalid config" {
+		t.Fatalf("unexpected parsed error: %+v", apiErr)
+	}
+	if len(apiErr.FieldErrors) != 1 {
+		t.Fatalf("expected a single field error, got %+v", apiErr.FieldErrors)
+	}
+	fieldErr := apiErr.FieldErrors[0]
+	if strings.Join(fieldErr.Path, ".") != "config_json.domains.1.domain" || fieldErr.Messages[0] != "Enter a valid domain." {
+		t.Fatalf("unexpected field error: %+v", fieldErr)
+	}
+
+	if parseAPIError(errors.New("connection refused")) != nil {
+		t.Fatal("expected non HTTP error not to be parsed")
+	}
+}
+
+func TestWrapAPIError(t *testing.T) {
+	err := wrapAPIError(errors.New("status: 404 Not Found, body: {\"detail\": \"Not found.\"}"))
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %T", err)
+	}
+	if !isNotFoundError(err) || apiErr.Detail != "Not found." {
+		t.Fatalf("unexpected error: %+v", apiErr)
+	}
+	if wrapAPIError(nil) != nil {
+		t.Fatal("expected nil error to stay nil")
+	}
+}
+
+func TestFieldErrorPath(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := resource.SchemaResponse{}
+	(&ServiceResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	cases := []struct {
+		fieldPath []string
+		expected  path.Path
+	}{
+		{[]string{"config_json", "domains", "1", "domain"}, path.Root("config").AtName("domains").AtListIndex(1).AtName("domain")},
+		{[]string{"service_config", "config_json", "geo_restriction"}, path.Root("config").AtName("geo_fencing")},
+		{[]string{"config_json", "unknown_field"}, path.Root("config")},
+		{[]string{"service", "name"}, path.Root("name")},
+		{[]string{"certificates"}, path.Root("certificates")},
+		{[]string{"account"}, path.Empty()},
+	}
+
+	for _, c := range cases {
+		p, ok := fieldErrorPath(ctx, schemaResp.Schema, c.fieldPath)
+		if !ok && !c.expected.Equal(path.Empty()) {
+			t.Errorf("%v: expected path %s, got none", c.fieldPath, c.expected)
+			continue
+		}
+		if ok && !p.Equal(c.expected) {
+			t.Errorf("%v: expected path %s, got %s", c.fieldPath, c.expected, p)
+		}
+	}
+}
+
+func TestAddAPIErrorDiagnostics(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := resource.SchemaResponse{}
+	(&ServiceResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	err := wrapAPIError(errors.New(`status: 400 Bad Request, body: {"request_id": "req-123", "config_json": {"domains": [{"domain": ["Enter a valid domain."]}]}}`))
+
+	var diags diag.Diagnostics
+	addAPIErrorDiagnostics(ctx, &diags, schemaResp.Schema, "Error updating resource", "Could not update resource", err)
+
+	if len(diags) != 1 {
+		t.Fatalf("expected a single diagnostic, got %v", diags)
+	}
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok || !withPath.Path().Equal(path.Root("config").AtName("domains").AtListIndex(0).AtName("domain")) {
+		t.Fatalf("expected an attribute diagnostic on the domain, got %v", diags[0])
+	}
+	if !strings.Contains(diags[0].Detail(), "Request ID: req-123") {
+		t.Fatalf("expected request id in the diagnostic, got %q", diags[0].Detail())
+	}
+
+	// errors which didn't come from the backend keep the generic message
+	diags = nil
+	addAPIErrorDiagnostics(ctx, &diags, schemaResp.Schema, "Error updating resource", "Could not update resource", errors.New("connection refused"))
+	if len(diags) != 1 || diags[0].Detail() != "Could not update resource, unexpected error: connection refused" {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Raw API calls. The ioriver-go client doesn't expose every endpoint used by
+// the provider, e.g. the config history of a service. These are the only calls
+// sent outside of the ioriver-go client: endpoints should be added to ioriver-go
+// rather than here, and moved to it once available.
+// ---------------------------------------------------------------------------
+
+// apiRequestTimeout limits a single raw call, retries are handled by the caller
+const apiRequestTimeout = 60 * time.Second
+
+var apiHTTPClient = &http.Client{Timeout: apiRequestTimeout}
+
+// apiGet reads an object from the API, see apiRequest
+func apiGet(ctx context.Context, client *Client, path string, out interface{}) error {
+	return apiRequest(ctx, client, http.MethodGet, path, nil, out)
+}
+
+// apiPost posts an object to the API, see apiRequest. A nil body is sent as an
+// empty object.
+func apiPost(ctx context.Context, client *Client, path string, body interface{}, out interface{}) error {
+	if body == nil {
+		body = struct{}{}
+	}
+	return apiRequest(ctx, client, http.MethodPost, path, body, out)
+}
+
+// apiRequest sends a request to path, relative to the v1 API of the client
+// endpoint, authenticated with the token of the client. The JSON response is
+// decoded into out. Failed responses are reported as an *APIError carrying the
+// status code and the Retry-After header, so they are retried by callIdempotent
+// and reported by addAPIErrorDiagnostics the same way as ioriver-go errors.
+func apiRequest(ctx context.Context, client *Client, method string, path string, body interface{}, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	url := strings.TrimSuffix(client.EndpointUrl, "/") + "/v1/" + path
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Token "+client.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "terraform-provider-ioriver/"+client.TerraformVersion)
+
+	res, err := apiHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode >= 300 {
+		return newResponseAPIError(res, resBody)
+	}
+	if out == nil || len(resBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resBody, out)
+}
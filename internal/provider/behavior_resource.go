@@ -15,7 +15,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	ioriver "github.com/ioriver/ioriver-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -645,19 +644,19 @@ func (r *BehaviorResource) ImportState(ctx context.Context, req resource.ImportS
 
 // ------- Implement base Resource API (stubs to satisfy interface) ---------
 
-func (BehaviorResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
+func (BehaviorResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (BehaviorResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
+func (BehaviorResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (BehaviorResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
+func (BehaviorResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (BehaviorResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
+func (BehaviorResource) delete(ctx context.Context, client *Client, id interface{}) error {
 	return nil
 }
 
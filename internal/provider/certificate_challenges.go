@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ChallengeRecordModel is a DNS record required to validate a MANAGED certificate
+type ChallengeRecordModel struct {
+	Domain types.String `tfsdk:"domain"`
+	Name   types.String `tfsdk:"name"`
+	Type   types.String `tfsdk:"type"`
+	Value  types.String `tfsdk:"value"`
+}
+
+// used for converting to ObjectValue
+func (m ChallengeRecordModel) AttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"domain": types.StringType,
+		"name":   types.StringType,
+		"type":   types.StringType,
+		"value":  types.StringType,
+	}
+}
+
+func challengeRecordAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"domain": schema.StringAttribute{
+			MarkdownDescription: "The domain validated by the record",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "DNS record name, e.g. _acme-challenge.www.example.com",
+			Computed:            true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "DNS record type, e.g. TXT or CNAME",
+			Computed:            true,
+		},
+		"value": schema.StringAttribute{
+			MarkdownDescription: "DNS record value",
+			Computed:            true,
+		},
+	}
+}
+
+// challengeRecord is a record of the challenges of a certificate. The backend
+// returns the challenges as a JSON list of records, e.g.
+// [{"domain": "www.example.com", "name": "_acme-challenge.www.example.com", "type": "CNAME", "value": "..."}]
+type challengeRecord struct {
+	Domain string `json:"domain"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+}
+
+// parseChallenges parses the challenges of a certificate, ordered by domain
+func parseChallenges(raw string) ([]challengeRecord, error) {
+	if strings.TrimSpace(raw) == "" {
+		return []challengeRecord{}, nil
+	}
+
+	var records []challengeRecord
+	if err := json.Unmarshal([]byte(raw), &records); err != nil {
+		return nil, fmt.Errorf("invalid challenges JSON: %w", err)
+	}
+	for i := range records {
+		record := &records[i]
+		if record.Domain == "" || record.Name == "" || record.Type == "" || record.Value == "" {
+			return nil, fmt.Errorf("challenge %d is missing its domain, record name, type or value", i)
+		}
+		record.Name = strings.TrimSuffix(record.Name, ".")
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Domain != records[j].Domain {
+			return records[i].Domain < records[j].Domain
+		}
+		return records[i].Name < records[j].Name
+	})
+	return records, nil
+}
+
+// addChallengesWarning reports challenges which can't be parsed, in which case
+// challenges_records is null
+func addChallengesWarning(diags *diag.Diagnostics, challenges string) {
+	if _, err := parseChallenges(challenges); err != nil {
+		addChallengesParseWarning(diags, err)
+	}
+}
+
+// addChallengesParseWarning reports the error of parsing the challenges of a
+// certificate
+func addChallengesParseWarning(diags *diag.Diagnostics, err error) {
+	diags.AddAttributeWarning(path.Root("challenges_records"), "Unexpected challenges format",
+		"Failed to parse the certificate challenges, use the challenges attribute instead: "+err.Error())
+}
+
+// challengesToList converts the challenges of a certificate to a list of ChallengeRecordModel
+func challengesToList(ctx context.Context, raw string) (types.List, error) {
+	recordType := types.ObjectType{AttrTypes: ChallengeRecordModel{}.AttributeTypes()}
+
+	records, err := parseChallenges(raw)
+	if err != nil {
+		return types.ListNull(recordType), err
+	}
+
+	values := []attr.Value{}
+	for _, record := range records {
+		value, diags := types.ObjectValueFrom(ctx, recordType.AttrTypes, ChallengeRecordModel{
+			Domain: types.StringValue(record.Domain),
+			Name:   types.StringValue(record.Name),
+			Type:   types.StringValue(record.Type),
+			Value:  types.StringValue(record.Value),
+		})
+		if diags.HasError() {
+			return types.ListNull(recordType), fmt.Errorf("failed to set challenge record object")
+		}
+		values = append(values, value)
+	}
+	list, diags := types.ListValue(recordType, values)
+	if diags.HasError() {
+		return types.ListNull(recordType), fmt.Errorf("failed to set challenges records field")
+	}
+	return list, nil
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestParseChallenges(t *testing.T) {
+	www := challengeRecord{Domain: "www.example.com", Name: "_acme-challenge.www.example.com", Type: "CNAME", Value: "abc.acm-validations.aws."}
+	apex := challengeRecord{Domain: "example.com", Name: "_acme-challenge.example.com", Type: "TXT", Value: "token-1"}
+
+	cases := []struct {
+		name     string
+		raw      string
+		expected []challengeRecord
+	}{
+		{"empty", "  ", []challengeRecord{}},
+		{"no challenges", "[]", []challengeRecord{}},
+		{"records", `[
+			{"domain": "www.example.com", "name": "_acme-challenge.www.example.com.", "type": "CNAME", "value": "abc.acm-validations.aws."},
+			{"domain": "example.com", "name": "_acme-challenge.example.com", "type": "TXT", "value": "token-1"}
+		]`, []challengeRecord{apex, www}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			records, err := parseChallenges(c.raw)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if fmt.Sprint(records) != fmt.Sprint(c.expected) {
+				t.Fatalf("expected %v, got %v", c.expected, records)
+			}
+		})
+	}
+
+	for _, raw := range []string{
+		`[{"domain": "example.com"}]`,
+		`{"domain": "example.com", "name": "_acme-challenge.example.com", "type": "TXT", "value": "token-1"}`,
+		`[{"name": `,
+		"_acme-challenge.example.com TXT token-1",
+	} {
+		if _, err := parseChallenges(raw); err == nil {
+			t.Errorf("expected an error for %q", raw)
+		}
+	}
+}
+
+func TestChallengesToList(t *testing.T) {
+	ctx := context.Background()
+
+	list, err := challengesToList(ctx, `[{"domain": "example.com", "name": "_acme-challenge.example.com", "type": "TXT", "value": "token-1"}]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	records := []ChallengeRecordModel{}
+	if diags := list.ElementsAs(ctx, &records, false); diags.HasError() {
+		t.Fatalf("failed to read records: %v", diags)
+	}
+	if len(records) != 1 || records[0].Domain.ValueString() != "example.com" || records[0].Value.ValueString() != "token-1" {
+		t.Fatalf("unexpected records: %v", records)
+	}
+
+	if list, err := challengesToList(ctx, "not a challenge"); err == nil || !list.IsNull() {
+		t.Fatalf("expected an error and a null list, got %v, %v", list, err)
+	}
+}
+
+func TestAddChallengesWarning(t *testing.T) {
+	var diags diag.Diagnostics
+	addChallengesWarning(&diags, "[]")
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	addChallengesWarning(&diags, "not a challenge")
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if withPath := diags[0].(diag.DiagnosticWithPath); !withPath.Path().Equal(path.Root("challenges_records")) {
+		t.Fatalf("unexpected warning path %s", withPath.Path())
+	}
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ioriver "github.com/ioriver/ioriver-go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CertificateDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &CertificateDataSource{}
+
+func NewCertificateDataSource() datasource.DataSource {
+	return &CertificateDataSource{}
+}
+
+type CertificateDataSource struct {
+	client *Client
+}
+
+type CertificateDataSourceModel struct {
+	Id                    types.String `tfsdk:"id"`
+	Name                  types.String `tfsdk:"name"`
+	Cn                    types.String `tfsdk:"cn"`
+	Type                  types.String `tfsdk:"type"`
+	Status                types.String `tfsdk:"status"`
+	NotValidAfter         types.String `tfsdk:"not_valid_after"`
+	Challenges            types.String `tfsdk:"challenges"`
+	ChallengesRecords     types.List   `tfsdk:"challenges_records"`
+	ProvidersCertificates types.Set    `tfsdk:"providers_certificates"`
+}
+
+func (d *CertificateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate"
+}
+
+func (d *CertificateDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Certificate data source. Looks up an existing certificate by id, name or CN.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Certificate identifier",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Certificate name",
+				Optional:            true,
+				Computed:            true,
+			},
+			"cn": schema.StringAttribute{
+				MarkdownDescription: "Certificate CN",
+				Optional:            true,
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Certificate type (MANAGED/SELF_MANAGED/EXTERNAL)",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Certificate status, `ISSUED` once the certificate is issued and `FAILED` when its issuance failed",
+				Computed:            true,
+			},
+			"not_valid_after": schema.StringAttribute{
+				MarkdownDescription: "Certificate expiration date",
+				Computed:            true,
+			},
+			"challenges": schema.StringAttribute{
+				MarkdownDescription: "Required DNS challenges",
+				Computed:            true,
+			},
+			"challenges_records": schema.ListNestedAttribute{
+				MarkdownDescription: "Required DNS challenges as DNS records. Empty when no challenges are required",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: computedAttributes(challengeRecordAttributes()),
+				},
+			},
+			"providers_certificates": schema.SetNestedAttribute{
+				MarkdownDescription: "Details of the certificate as it is deployed on each provider",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"account_provider": schema.StringAttribute{
+							MarkdownDescription: "The account provider of the provider certificate",
+							Computed:            true,
+						},
+						"provider_certificate_id": schema.StringAttribute{
+							MarkdownDescription: "The id of the certificate within the provider",
+							Computed:            true,
+						},
+						"not_valid_after": schema.StringAttribute{
+							MarkdownDescription: "Certificate expiration date",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CertificateDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+			path.MatchRoot("cn"),
+		),
+	}
+}
+
+// Configure data source and retrieve API client
+func (d *CertificateDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	client := ConfigureDataSourceBase(ctx, req, resp)
+	if client == nil {
+		return
+	}
+	d.client = client
+}
+
+// Read Certificate data source
+func (d *CertificateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CertificateDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.Id.ValueString()
+	if data.Id.IsNull() {
+		found, err := findCertificate(ctx, d.client, data.Name, data.Cn)
+		if err != nil {
+			addAPIErrorDiagnostics(ctx, &resp.Diagnostics, nil, "Client Error", "Unable to find certificate", err)
+			return
+		}
+		id = found.Id
+	}
+
+	cert, err := callIdempotent(ctx, d.client, func() (*ioriver.Certificate, error) { return d.client.GetCertificate(id) })
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, nil, "Client Error", "Unable to read certificate", err)
+		return
+	}
+
+	providersCertsValue, err := providersCertificatesToSet(ctx, cert.ProvidersCertificates)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Failed to convert IORiver object to data source: "+err.Error())
+		return
+	}
+
+	challengesRecords, err := challengesToList(ctx, cert.Challenges)
+	if err != nil {
+		// challenges_records is null, the raw challenges are still set
+		addChallengesParseWarning(&resp.Diagnostics, err)
+	}
+
+	newData := CertificateDataSourceModel{
+		Id:                    types.StringValue(cert.Id),
+		Name:                  types.StringValue(cert.Name),
+		Cn:                    types.StringValue(cert.Cn),
+		Type:                  types.StringValue(string(cert.Type)),
+		Status:                types.StringValue(string(cert.Status)),
+		NotValidAfter:         types.StringValue(cert.NotValidAfter),
+		Challenges:            types.StringValue(cert.Challenges),
+		ChallengesRecords:     challengesRecords,
+		ProvidersCertificates: providersCertsValue,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newData)...)
+}
+
+// findCertificate looks up a single certificate by its name or CN
+func findCertificate(ctx context.Context, client *Client, name types.String, cn types.String) (*ioriver.Certificate, error) {
+	certs, err := callIdempotent(ctx, client, client.ListCertificates)
+	if err != nil {
+		return nil, err
+	}
+
+	field, value := "name", name.ValueString()
+	if name.IsNull() {
+		field, value = "CN", cn.ValueString()
+	}
+
+	matches := []ioriver.Certificate{}
+	for _, cert := range certs {
+		if (!name.IsNull() && cert.Name == value) || (name.IsNull() && cert.Cn == value) {
+			matches = append(matches, cert)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no certificate with %s %q was found", field, value)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := []string{}
+		for _, cert := range matches {
+			ids = append(ids, cert.Id)
+		}
+		return nil, fmt.Errorf("found %d certificates with %s %q, use the certificate id instead: %v", len(matches), field, value, ids)
+	}
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
+)
+
+func TestAccIORiverCertificateDataSource_Basic(t *testing.T) {
+	rndName := generateRandomResourceName()
+	resourceName := certResourceType + "." + rndName
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCertificateDataSourceConfig(rndName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ioriver_certificate.by_id", "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair("data.ioriver_certificate.by_id", "not_valid_after", resourceName, "not_valid_after"),
+					resource.TestCheckResourceAttrPair("data.ioriver_certificate.by_name", "id", resourceName, "id"),
+					resource.TestCheckResourceAttr("data.ioriver_certificate.by_name", "type", "SELF_MANAGED"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCertificateDataSourceConfig(rndName string) string {
+	return testAccCheckCertificateConfig(rndName, rndName) + fmt.Sprintf(`
+
+data "ioriver_certificate" "by_id" {
+	id = ioriver_certificate.%[1]s.id
+}
+
+data "ioriver_certificate" "by_name" {
+	name = ioriver_certificate.%[1]s.name
+}`, rndName)
+}
+
+func TestFindCertificate(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	webId := server.AddCertificate("web", "MANAGED", "www.example.com")
+	server.AddCertificate("api", "MANAGED", "api.example.com")
+	server.AddCertificate("api-renewed", "MANAGED", "api.example.com")
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	cert, err := findCertificate(ctx, client, types.StringValue("web"), types.StringNull())
+	if err != nil || cert.Id != webId {
+		t.Fatalf("expected to find certificate %s by name, got %v, %v", webId, cert, err)
+	}
+
+	cert, err = findCertificate(ctx, client, types.StringNull(), types.StringValue("www.example.com"))
+	if err != nil || cert.Id != webId {
+		t.Fatalf("expected to find certificate %s by CN, got %v, %v", webId, cert, err)
+	}
+
+	if _, err := findCertificate(ctx, client, types.StringNull(), types.StringValue("api.example.com")); err == nil {
+		t.Fatal("expected an error for a CN matching several certificates")
+	}
+	if _, err := findCertificate(ctx, client, types.StringValue("missing"), types.StringNull()); err == nil {
+		t.Fatal("expected an error for a missing certificate")
+	}
+}
+
+func TestCertificateDataSourceRead_InvalidChallenges(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	var cert map[string]interface{}
+	err := apiPost(ctx, client, "certificates/", map[string]interface{}{
+		"name":       "managed",
+		"type":       "MANAGED",
+		"cn":         "www.example.com",
+		"status":     "PENDING",
+		"challenges": "_acme-challenge.www.example.com CNAME validation.example.net",
+	}, &cert)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %s", err)
+	}
+
+	schemaResp := datasource.SchemaResponse{}
+	(&CertificateDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	nullValue := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	config := tfsdk.State{Schema: schemaResp.Schema, Raw: nullValue}
+	if diags := config.SetAttribute(ctx, path.Root("id"), cert["id"].(string)); diags.HasError() {
+		t.Fatalf("failed to set data source config: %v", diags)
+	}
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: nullValue}}
+	(&CertificateDataSource{client: client}).Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics[0].Summary() != "Unexpected challenges format" {
+		t.Fatalf("expected a single warning, got %v", resp.Diagnostics)
+	}
+
+	var data CertificateDataSourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("failed to read data source state: %v", diags)
+	}
+	if !data.ChallengesRecords.IsNull() || data.Challenges.ValueString() == "" {
+		t.Fatalf("expected only the raw challenges, got %s, %s", data.Challenges, data.ChallengesRecords)
+	}
+}
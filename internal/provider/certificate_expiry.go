@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ioriver/ioriver-go"
+)
+
+// only certificates of this type are issued by the backend, and replaced when
+// expiring
+const managedCertificateType = "MANAGED"
+
+// formats of not_valid_after returned by the API
+var certificateExpiryFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// certificateExpiry is an expiration date of a certificate or of one of its copies
+// on the providers
+type certificateExpiry struct {
+	// empty for the certificate itself
+	AccountProvider string
+	NotValidAfter   time.Time
+}
+
+func (e certificateExpiry) String() string {
+	date := e.NotValidAfter.UTC().Format(time.RFC3339)
+	if e.AccountProvider == "" {
+		return "the certificate expires on " + date
+	}
+	return fmt.Sprintf("the copy on account provider %s expires on %s", e.AccountProvider, date)
+}
+
+func parseCertificateExpiry(value string) (time.Time, error) {
+	for _, format := range certificateExpiryFormats {
+		if t, err := time.Parse(format, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unexpected expiration date format %q", value)
+}
+
+// expiringCertificates returns the expiration dates of the certificate and its
+// provider copies which are before the deadline, earliest first. Missing or
+// unparsable dates are skipped.
+func expiringCertificates(ctx context.Context, cert CertificateResourceModel, providersCertificates []ioriver.ProviderCertificate, deadline time.Time) []certificateExpiry {
+	expiries := []certificateExpiry{}
+	add := func(accountProvider string, value types.String) {
+		if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+			return
+		}
+		notValidAfter, err := parseCertificateExpiry(value.ValueString())
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Failed to check the expiration of certificate %s: %s", cert.Id.ValueString(), err))
+			return
+		}
+		if notValidAfter.Before(deadline) {
+			expiries = append(expiries, certificateExpiry{AccountProvider: accountProvider, NotValidAfter: notValidAfter})
+		}
+	}
+
+	add("", cert.NotValidAfter)
+
+	for _, providerCert := range providersCertificates {
+		add(providerCert.AccountProvider, types.StringValue(providerCert.NotValidAfter))
+	}
+
+	sort.SliceStable(expiries, func(i, j int) bool {
+		return expiries[i].NotValidAfter.Before(expiries[j].NotValidAfter)
+	})
+	return expiries
+}
+
+// certificateProviderCopies returns the copies of a certificate on the providers.
+// They are read from the API, since the state only holds them for EXTERNAL
+// certificates. The copies in state are used when the API can't be read.
+func certificateProviderCopies(ctx context.Context, client *Client, cert CertificateResourceModel) ([]ioriver.ProviderCertificate, diag.Diagnostics) {
+	if client != nil {
+		certId := cert.Id.ValueString()
+		current, err := callIdempotent(ctx, client, func() (*ioriver.Certificate, error) { return client.GetCertificate(certId) })
+		if err == nil {
+			return current.ProvidersCertificates, nil
+		}
+		tflog.Warn(ctx, fmt.Sprintf("Failed to read the provider copies of certificate %s: %s", certId, err))
+	}
+
+	var diags diag.Diagnostics
+	providersCerts := []ProviderCertificateModel{}
+	if !cert.ProvidersCertificates.IsNull() && !cert.ProvidersCertificates.IsUnknown() {
+		diags = cert.ProvidersCertificates.ElementsAs(ctx, &providersCerts, false)
+	}
+	copies := make([]ioriver.ProviderCertificate, 0, len(providersCerts))
+	for _, providerCert := range providersCerts {
+		copies = append(copies, ioriver.ProviderCertificate{
+			AccountProvider: providerCert.AccountProvider.ValueString(),
+			NotValidAfter:   providerCert.NotValidAfter.ValueString(),
+		})
+	}
+	return copies, diags
+}
+
+// checkCertificateExpiry warns about a certificate, or one of its copies on the
+// providers, which expires within renew_before_days, or plans its replacement
+// when replace_when_expiring is set.
+// Only MANAGED certificates are replaced: the others would be created again with
+// the same content, and so the same expiration date.
+func checkCertificateExpiry(ctx context.Context, client *Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, now time.Time) {
+	// nothing to check on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state CertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.RenewBeforeDays.IsNull() || plan.RenewBeforeDays.IsUnknown() {
+		return
+	}
+
+	renewBeforeDays := plan.RenewBeforeDays.ValueInt64()
+	providersCertificates, diags := certificateProviderCopies(ctx, client, state)
+	resp.Diagnostics.Append(diags...)
+	expiries := expiringCertificates(ctx, state, providersCertificates, now.AddDate(0, 0, int(renewBeforeDays)))
+	if len(expiries) == 0 {
+		return
+	}
+
+	details := fmt.Sprintf("Certificate %s (%s) expires within the %d days of renew_before_days:", state.Name.ValueString(), state.Id.ValueString(), renewBeforeDays)
+	for _, expiry := range expiries {
+		details += "\n  - " + expiry.String()
+	}
+
+	if state.Type.ValueString() != managedCertificateType {
+		resp.Diagnostics.AddAttributeWarning(path.Root("not_valid_after"), "Certificate expires soon",
+			details+"\n\nRenew the certificate and update its content, replace_when_expiring only replaces MANAGED certificates.")
+		return
+	}
+	if !plan.ReplaceWhenExpiring.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(path.Root("not_valid_after"), "Certificate expires soon",
+			details+"\n\nRenew the certificate, or set replace_when_expiring to replace it.")
+		return
+	}
+
+	// Terraform only replaces a resource when a value which requires replacement
+	// changes, and the expiration date of the new certificate is not known yet
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("not_valid_after"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("not_valid_after"))
+	resp.Diagnostics.AddAttributeWarning(path.Root("not_valid_after"), "Certificate will be replaced",
+		details+"\n\nThe certificate is replaced since replace_when_expiring is set.")
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
+)
+
+func TestParseCertificateExpiry(t *testing.T) {
+	expected := time.Date(2026, 11, 1, 12, 30, 0, 0, time.UTC)
+	for _, value := range []string{"2026-11-01T12:30:00Z", "2026-11-01T12:30:00.000000Z", "2026-11-01T12:30:00", "2026-11-01 12:30:00"} {
+		parsed, err := parseCertificateExpiry(value)
+		if err != nil || !parsed.Equal(expected) {
+			t.Fatalf("expected %q to be parsed as %s, got %s, %v", value, expected, parsed, err)
+		}
+	}
+	if _, err := parseCertificateExpiry("next week"); err == nil {
+		t.Fatal("expected an error for an invalid date")
+	}
+}
+
+func TestCheckCertificateExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	schemaResp := resource.SchemaResponse{}
+	(&CertificateResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	certSchema := schemaResp.Schema
+
+	state := func(certType string, notValidAfter string, awsNotValidAfter string, renewBeforeDays int64, replace bool) tfsdk.State {
+		state := tfsdk.State{Schema: certSchema, Raw: tftypes.NewValue(certSchema.Type().TerraformType(ctx), nil)}
+		providersCerts, diags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: ProviderCertificateModel{}.AttributeTypes()}, []ProviderCertificateModel{{
+			AccountProvider:       types.StringValue("aws-account"),
+			ProviderCertificateId: types.StringValue("arn"),
+			NotValidAfter:         types.StringValue(awsNotValidAfter),
+		}})
+		diags.Append(state.SetAttribute(ctx, path.Root("id"), "cert-id")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("name"), "cert")...)
+		diags.Append(state.SetAttribute(ctx, path.Root("type"), certType)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("not_valid_after"), notValidAfter)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("providers_certificates"), providersCerts)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("renew_before_days"), renewBeforeDays)...)
+		diags.Append(state.SetAttribute(ctx, path.Root("replace_when_expiring"), replace)...)
+		if diags.HasError() {
+			t.Fatalf("failed to build state: %v", diags)
+		}
+		return state
+	}
+	plan := func(state tfsdk.State) tfsdk.Plan {
+		return tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}
+	}
+	check := func(state tfsdk.State) resource.ModifyPlanResponse {
+		resp := resource.ModifyPlanResponse{Plan: plan(state)}
+		checkCertificateExpiry(ctx, nil, resource.ModifyPlanRequest{State: state, Plan: plan(state)}, &resp, now)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		return resp
+	}
+
+	// far from expiring
+	resp := check(state("MANAGED", "2027-01-01T00:00:00Z", "2027-01-01T00:00:00Z", 30, false))
+	if len(resp.Diagnostics) != 0 || len(resp.RequiresReplace) != 0 {
+		t.Fatalf("expected no warnings, got %v", resp.Diagnostics)
+	}
+
+	// the copy on a provider expires soon
+	resp = check(state("MANAGED", "2027-01-01T00:00:00Z", "2026-10-20T00:00:00Z", 30, false))
+	if len(resp.Diagnostics) != 1 || len(resp.RequiresReplace) != 0 {
+		t.Fatalf("expected a single warning, got %v", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics[0].Detail(); !strings.Contains(detail, "account provider aws-account expires on 2026-10-20") ||
+		strings.Contains(detail, "the certificate expires") {
+		t.Fatalf("unexpected warning: %s", detail)
+	}
+
+	// replaced when expiring
+	resp = check(state("MANAGED", "2026-10-10T00:00:00Z", "2027-01-01T00:00:00Z", 30, true))
+	if len(resp.RequiresReplace) != 1 {
+		t.Fatalf("expected the certificate to be replaced, got %v", resp.RequiresReplace)
+	}
+	var notValidAfter types.String
+	resp.Plan.GetAttribute(ctx, path.Root("not_valid_after"), &notValidAfter)
+	if !notValidAfter.IsUnknown() {
+		t.Fatalf("expected the planned expiration date to be unknown, got %s", notValidAfter)
+	}
+
+	// other certificates would be created again with the same expiration date
+	for _, certType := range []string{"SELF_MANAGED", "EXTERNAL"} {
+		resp = check(state(certType, "2026-10-10T00:00:00Z", "2027-01-01T00:00:00Z", 30, true))
+		if len(resp.RequiresReplace) != 0 || len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary() != "Certificate expires soon" {
+			t.Fatalf("expected a %s certificate to only be warned about, got %v, %v", certType, resp.RequiresReplace, resp.Diagnostics)
+		}
+	}
+}
+
+func TestCheckCertificateExpiry_ProviderCopiesFromAPI(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	// the state only holds the provider copies of EXTERNAL certificates
+	var cert map[string]interface{}
+	err := apiPost(ctx, client, "certificates/", map[string]interface{}{
+		"name":            "cert",
+		"type":            "SELF_MANAGED",
+		"not_valid_after": "2027-01-01T00:00:00Z",
+		"providers_certificates": []map[string]interface{}{{
+			"account_provider":        "aws-account",
+			"provider_certificate_id": "arn",
+			"not_valid_after":         "2026-10-20T00:00:00Z",
+		}},
+	}, &cert)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %s", err)
+	}
+
+	schemaResp := resource.SchemaResponse{}
+	(&CertificateResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	var diags diag.Diagnostics
+	diags.Append(state.SetAttribute(ctx, path.Root("id"), cert["id"].(string))...)
+	diags.Append(state.SetAttribute(ctx, path.Root("name"), "cert")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("type"), "SELF_MANAGED")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("not_valid_after"), "2027-01-01T00:00:00Z")...)
+	diags.Append(state.SetAttribute(ctx, path.Root("renew_before_days"), int64(30))...)
+	if diags.HasError() {
+		t.Fatalf("failed to build state: %v", diags)
+	}
+
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	checkCertificateExpiry(ctx, client, resource.ModifyPlanRequest{State: state, Plan: plan}, &resp, now)
+	if resp.Diagnostics.HasError() || len(resp.Diagnostics) != 1 {
+		t.Fatalf("expected a single warning, got %v", resp.Diagnostics)
+	}
+	if detail := resp.Diagnostics[0].Detail(); !strings.Contains(detail, "account provider aws-account expires on 2026-10-20") {
+		t.Fatalf("unexpected warning: %s", detail)
+	}
+}
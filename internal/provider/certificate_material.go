@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// certificateFingerprint returns the SHA-256 fingerprint of the first PEM encoded
+// certificate, in the format printed by `openssl x509 -fingerprint -sha256`
+func certificateFingerprint(certificate string) (string, error) {
+	rest := []byte(certificate)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return "", errors.New("no PEM encoded certificate was found")
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		sum := sha256.Sum256(block.Bytes)
+		parts := make([]string, len(sum))
+		for i, b := range sum {
+			parts[i] = fmt.Sprintf("%02X", b)
+		}
+		return strings.Join(parts, ":"), nil
+	}
+}
+
+// mergeCertificateMaterialFromConfig copies the write-only certificate content
+// from the config into the plan, and sets the fingerprint of the certificate. The
+// content is sent with every update, as it was before it became write-only, so
+// that updating another attribute doesn't send an empty certificate, and the
+// fingerprint always matches the uploaded certificate.
+func mergeCertificateMaterialFromConfig(planData *CertificateResourceModel, configData CertificateResourceModel) error {
+	planData.Certificate = configData.Certificate
+	planData.PrivateKey = configData.PrivateKey
+	planData.CertificateChain = configData.CertificateChain
+
+	planData.Fingerprint = types.StringNull()
+	if configData.Certificate.ValueString() != "" {
+		fingerprint, err := certificateFingerprint(configData.Certificate.ValueString())
+		if err != nil {
+			return err
+		}
+		planData.Fingerprint = types.StringValue(fingerprint)
+	}
+	return nil
+}
+
+// planCertificateFingerprint sets the planned fingerprint of the configured
+// certificate, which is uploaded on create and on every update. Editing the
+// certificate is planned as a change of its fingerprint.
+func planCertificateFingerprint(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planData, configData CertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planData)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fingerprint := types.StringUnknown()
+	if !configData.Certificate.IsUnknown() {
+		if err := mergeCertificateMaterialFromConfig(&planData, configData); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("certificate"), "Invalid certificate", err.Error())
+			return
+		}
+		fingerprint = planData.Fingerprint
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("fingerprint"), fingerprint)...)
+}
@@ -3,23 +3,30 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ioriver/ioriver-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CertificateResource{}
 var _ resource.ResourceWithImportState = &CertificateResource{}
+var _ ScopedResource = &CertificateResource{}
+var _ resource.ResourceWithModifyPlan = &CertificateResource{}
+var _ resource.ResourceWithUpgradeState = &CertificateResource{}
 
 func NewCertificateResource() resource.Resource {
 	return &CertificateResource{}
@@ -27,7 +34,7 @@ func NewCertificateResource() resource.Resource {
 
 type CertificateResourceId = string
 type CertificateResource struct {
-	client *ioriver.IORiverClient
+	client *Client
 }
 
 type ProviderCertificateModel struct {
@@ -46,17 +53,23 @@ func (m ProviderCertificateModel) AttributeTypes() map[string]attr.Type {
 }
 
 type CertificateResourceModel struct {
-	Id                    types.String `tfsdk:"id"`
-	Name                  types.String `tfsdk:"name"`
-	Type                  types.String `tfsdk:"type"`
-	Cn                    types.String `tfsdk:"cn"`
-	NotValidAfter         types.String `tfsdk:"not_valid_after"`
-	Certificate           types.String `tfsdk:"certificate"`
-	PrivateKey            types.String `tfsdk:"private_key"`
-	CertificateChain      types.String `tfsdk:"certificate_chain"`
-	Challenges            types.String `tfsdk:"challenges"`
-	Status                types.String `tfsdk:"status"`
-	ProvidersCertificates types.Set    `tfsdk:"providers_certificates"`
+	Id                    types.String   `tfsdk:"id"`
+	Name                  types.String   `tfsdk:"name"`
+	Type                  types.String   `tfsdk:"type"`
+	Cn                    types.String   `tfsdk:"cn"`
+	NotValidAfter         types.String   `tfsdk:"not_valid_after"`
+	Certificate           types.String   `tfsdk:"certificate"`       // WriteOnly — never stored in state, not returned by API
+	PrivateKey            types.String   `tfsdk:"private_key"`       // WriteOnly — never stored in state, not returned by API
+	CertificateChain      types.String   `tfsdk:"certificate_chain"` // WriteOnly — never stored in state, not returned by API
+	ContentVersion        types.Int64    `tfsdk:"content_version"`   // TF-only counter; increment to upload new content
+	Fingerprint           types.String   `tfsdk:"fingerprint"`
+	Challenges            types.String   `tfsdk:"challenges"`
+	ChallengesRecords     types.List     `tfsdk:"challenges_records"`
+	Status                types.String   `tfsdk:"status"`
+	ProvidersCertificates types.Set      `tfsdk:"providers_certificates"`
+	RenewBeforeDays       types.Int64    `tfsdk:"renew_before_days"`
+	ReplaceWhenExpiring   types.Bool     `tfsdk:"replace_when_expiring"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *CertificateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -66,6 +79,7 @@ func (r *CertificateResource) Metadata(ctx context.Context, req resource.Metadat
 func (r *CertificateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Certificate resource",
+		Version:             1,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -102,34 +116,78 @@ func (r *CertificateResource) Schema(ctx context.Context, req resource.SchemaReq
 				Computed:            true,
 			},
 			"certificate": schema.StringAttribute{
-				MarkdownDescription: "Certificate content",
+				MarkdownDescription: "Certificate content (write-only, never stored in state)",
 				Optional:            true,
-				Computed:            true,
+				WriteOnly:           true,
 				Sensitive:           true,
-				Default:             stringdefault.StaticString(""),
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("content_version")),
+				},
 			},
 			"private_key": schema.StringAttribute{
-				MarkdownDescription: "Certificate private key",
+				MarkdownDescription: "Certificate private key (write-only, never stored in state)",
 				Optional:            true,
-				Computed:            true,
+				WriteOnly:           true,
 				Sensitive:           true,
-				Default:             stringdefault.StaticString(""),
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("content_version")),
+				},
 			},
 			"certificate_chain": schema.StringAttribute{
-				MarkdownDescription: "Certificate chain",
+				MarkdownDescription: "Certificate chain (write-only, never stored in state)",
 				Optional:            true,
-				Computed:            true,
+				WriteOnly:           true,
 				Sensitive:           true,
-				Default:             stringdefault.StaticString(""),
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("content_version")),
+				},
+			},
+			"content_version": schema.Int64Attribute{
+				MarkdownDescription: "Increment this value to upload the certificate content again. The content is sent on create and with every update, " +
+					"and editing the certificate is planned as a change of `fingerprint`. Changes of the private key or chain alone are only " +
+					"uploaded with the next update, increment this value to upload them.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 fingerprint of the configured certificate, which is uploaded with every update, in the format printed by `openssl x509 -fingerprint -sha256`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"challenges": schema.StringAttribute{
 				MarkdownDescription: "Required DNS challenges",
 				Computed:            true,
 			},
+			"challenges_records": schema.ListNestedAttribute{
+				MarkdownDescription: "Required DNS challenges as DNS records, which can be created with the DNS provider of the domains. Empty when no challenges are required",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: challengeRecordAttributes(),
+				},
+			},
 			"status": schema.StringAttribute{
-				MarkdownDescription: "Certificate status",
+				MarkdownDescription: "Certificate status, `ISSUED` once the certificate is issued and `FAILED` when its issuance failed",
 				Computed:            true,
 			},
+			"renew_before_days": schema.Int64Attribute{
+				MarkdownDescription: "Plans warn when the certificate, or its copy on any provider, expires within this number of days",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"replace_when_expiring": schema.BoolAttribute{
+				MarkdownDescription: "Replace a MANAGED certificate when it expires within `renew_before_days`, instead of only warning. " +
+					"Other certificates are renewed by updating their content, and are only warned about",
+				Optional: true,
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("renew_before_days")),
+				},
+			},
 			"providers_certificates": schema.SetNestedAttribute{
 				MarkdownDescription: "Details of the certificate as it is deployed on each provider. This field is required only for EXTERNAL certificates.",
 				Optional:            true,
@@ -154,6 +212,13 @@ func (r *CertificateResource) Schema(ctx context.Context, req resource.SchemaReq
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -171,19 +236,33 @@ func (r *CertificateResource) Create(ctx context.Context, req resource.CreateReq
 	var data CertificateResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The certificate content is WriteOnly, so it is only available in the config
+	var configData CertificateResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := mergeCertificateMaterialFromConfig(&data, configData); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("certificate"), "Invalid certificate", err.Error())
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	newData := resourceCreate(r.client, ctx, req, resp, r, data, false)
 	if newData == nil {
 		return
 	}
 
-	// Certificate has couple of write-only fields which we need to preserve from original request
 	newCert := newData.(CertificateResourceModel)
-	if newCert.Type.ValueString() == "SELF_MANAGED" {
-		newCert.Certificate = data.Certificate
-		newCert.PrivateKey = data.PrivateKey
-		newCert.CertificateChain = data.CertificateChain
-	}
-
+	addChallengesWarning(&resp.Diagnostics, newCert.Challenges.ValueString())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newCert)...)
 }
 
@@ -198,14 +277,8 @@ func (r *CertificateResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	// Certificate has couple of write-only fields which we need to preserve from original request
 	newCert := newData.(CertificateResourceModel)
-	if newCert.Type.ValueString() == "SELF_MANAGED" {
-		newCert.Certificate = data.Certificate
-		newCert.PrivateKey = data.PrivateKey
-		newCert.CertificateChain = data.CertificateChain
-	}
-
+	addChallengesWarning(&resp.Diagnostics, newCert.Challenges.ValueString())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newCert)...)
 }
 
@@ -215,20 +288,34 @@ func (r *CertificateResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The certificate content is WriteOnly, so it is only available in the config.
+	// It is sent with every update so that it is kept by the backend.
+	var configData CertificateResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := mergeCertificateMaterialFromConfig(&data, configData); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("certificate"), "Invalid certificate", err.Error())
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	newData := resourceUpdate(r.client, ctx, req, resp, r, data)
 	if newData == nil {
 		return
 	}
 
-	// Certificate has couple of write-only fields which we need to preserve from original request
 	updatedCert := newData.(CertificateResourceModel)
-	if updatedCert.Type.ValueString() == "SELF_MANAGED" {
-		updatedCert.Certificate = data.Certificate
-		updatedCert.PrivateKey = data.PrivateKey
-		updatedCert.CertificateChain = data.CertificateChain
-	}
-
+	addChallengesWarning(&resp.Diagnostics, updatedCert.Challenges.ValueString())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &updatedCert)...)
 }
 
@@ -240,35 +327,96 @@ func (r *CertificateResource) Delete(ctx context.Context, req resource.DeleteReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	resourceDelete(r.client, ctx, req, resp, r, data)
 }
 
+// ModifyPlan sets the fingerprint of uploaded content and checks the expiration of the certificate
+func (r *CertificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planCertificateFingerprint(ctx, req, resp)
+	checkCertificateExpiry(ctx, r.client, req, resp, time.Now())
+}
+
 // Import Certificate resource
 func (r *CertificateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+func (r *CertificateResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	// Version 0 stored the certificate content in state. The attributes are the
+	// same, except that the content was not write-only.
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	priorSchema := schemaResp.Schema
+	priorSchema.Version = 0
+	priorSchema.Attributes = make(map[string]schema.Attribute, len(schemaResp.Schema.Attributes))
+	for name, attribute := range schemaResp.Schema.Attributes {
+		priorSchema.Attributes[name] = attribute
+	}
+	for _, name := range []string{"certificate", "private_key", "certificate_chain"} {
+		priorSchema.Attributes[name] = schema.StringAttribute{Optional: true, Computed: true, Sensitive: true}
+	}
+
+	return map[int64]resource.StateUpgrader{
+		// State upgrade from 0 to 1: drop the certificate content from state and keep its fingerprint
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var data CertificateResourceModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data.Fingerprint = types.StringNull()
+				if data.Certificate.ValueString() != "" {
+					if fingerprint, err := certificateFingerprint(data.Certificate.ValueString()); err == nil {
+						data.Fingerprint = types.StringValue(fingerprint)
+					}
+				}
+				data.Certificate = types.StringNull()
+				data.PrivateKey = types.StringNull()
+				data.CertificateChain = types.StringNull()
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
 // ------- Implement base Resource API ---------
 
-func (CertificateResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
-	obj, err := client.CreateCertificate(newObj.(ioriver.Certificate))
+func (CertificateResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
+	obj, err := callCreate(ctx, client, func() (*ioriver.Certificate, error) {
+		return client.CreateCertificate(newObj.(ioriver.Certificate))
+	})
 	if err == nil {
 		// certificates challenges are updated after async task completes, so we need to retrieve the object again in order to get the challenges.
-		obj, err = client.GetCertificate(obj.Id)
+		id := obj.Id
+		obj, err = callIdempotent(ctx, client, func() (*ioriver.Certificate, error) { return client.GetCertificate(id) })
 	}
 	return obj, err
 }
 
-func (CertificateResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
-	return client.GetCertificate(id.(CertificateResourceId))
+func (CertificateResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
+	return callIdempotent(ctx, client, func() (*ioriver.Certificate, error) {
+		return client.GetCertificate(id.(CertificateResourceId))
+	})
 }
 
-func (CertificateResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
-	return client.UpdateCertificate(obj.(ioriver.Certificate))
+func (CertificateResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
+	return callIdempotent(ctx, client, func() (*ioriver.Certificate, error) {
+		return client.UpdateCertificate(obj.(ioriver.Certificate))
+	})
 }
 
-func (CertificateResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
-	return client.DeleteCertificate(id.(CertificateResourceId))
+func (CertificateResource) delete(ctx context.Context, client *Client, id interface{}) error {
+	return callDelete(ctx, client, func() error { return client.DeleteCertificate(id.(CertificateResourceId)) })
 }
 
 func (CertificateResource) getId(data interface{}) interface{} {
@@ -276,6 +424,10 @@ func (CertificateResource) getId(data interface{}) interface{} {
 	return d.Id.ValueString()
 }
 
+func (CertificateResource) lockScope(data interface{}) string {
+	return CertificatesLockScope
+}
+
 // Convert Certificate resource to Certificate API object
 func (CertificateResource) resourceToObj(ctx context.Context, data interface{}) (interface{}, error) {
 
@@ -313,39 +465,106 @@ func (CertificateResource) objToResource(ctx context.Context, obj interface{}, d
 	// convert providers certificates only for EXTERNAL certificates, otherwise it should be empty.
 	// We have to ignore it for non EXTERNAL, otherwise since this optional terraform field, terraform will try to set it
 	// to an empty set.
-	modelProvidersCertificates := []attr.Value{}
+	providersCertificates := []ioriver.ProviderCertificate{}
 	if cert.Type == "EXTERNAL" {
-		for _, providerCert := range cert.ProvidersCertificates {
-			value := ProviderCertificateModel{
-				AccountProvider:       types.StringValue(providerCert.AccountProvider),
-				ProviderCertificateId: types.StringValue(providerCert.ProviderCertificateId),
-				NotValidAfter:         types.StringValue(providerCert.NotValidAfter),
-			}
-
-			objectValue, diags := types.ObjectValueFrom(ctx, value.AttributeTypes(), value)
-			if diags.HasError() {
-				return nil, fmt.Errorf("failed to set provider certificate object")
-			}
-			modelProvidersCertificates = append(modelProvidersCertificates, objectValue)
-		}
+		providersCertificates = cert.ProvidersCertificates
 	}
-	certsModelAttr := ProviderCertificateModel{}.AttributeTypes()
-	providersCertsValue, diags := types.SetValue(types.ObjectType{AttrTypes: certsModelAttr}, modelProvidersCertificates)
-	if diags.HasError() {
-		return nil, fmt.Errorf("failed to set providers certificates field")
+	providersCertsValue, err := providersCertificatesToSet(ctx, providersCertificates)
+	if err != nil {
+		return nil, err
 	}
 
+	// challenges which can't be parsed are reported by addChallengesWarning
+	challengesRecords, _ := challengesToList(ctx, cert.Challenges)
+
 	return CertificateResourceModel{
 		Id:                    types.StringValue(cert.Id),
 		Name:                  types.StringValue(cert.Name),
 		Type:                  types.StringValue(string(cert.Type)),
 		Cn:                    types.StringValue(cert.Cn),
 		NotValidAfter:         types.StringValue(cert.NotValidAfter),
-		Certificate:           types.StringValue(""),
-		PrivateKey:            types.StringValue(""),
-		CertificateChain:      types.StringValue(""),
+		Certificate:           types.StringNull(),
+		PrivateKey:            types.StringNull(),
+		CertificateChain:      types.StringNull(),
 		Challenges:            types.StringValue(cert.Challenges),
+		ChallengesRecords:     challengesRecords,
 		Status:                types.StringValue(string(cert.Status)),
 		ProvidersCertificates: providersCertsValue,
+		// not returned by the API
+		ContentVersion:      data.(CertificateResourceModel).ContentVersion,
+		Fingerprint:         data.(CertificateResourceModel).Fingerprint,
+		RenewBeforeDays:     data.(CertificateResourceModel).RenewBeforeDays,
+		ReplaceWhenExpiring: data.(CertificateResourceModel).ReplaceWhenExpiring,
+		Timeouts:            data.(CertificateResourceModel).Timeouts,
 	}, nil
 }
+
+// Certificate statuses reported in the status attribute
+const (
+	certificateStatusIssued ioriver.CertificateStatus = "ISSUED"
+	certificateStatusFailed ioriver.CertificateStatus = "FAILED"
+)
+
+// waitForCertificateIssued polls the certificate until it is issued. A failed
+// issuance ends the wait.
+func waitForCertificateIssued(ctx context.Context, client *Client, cert *ioriver.Certificate) (*ioriver.Certificate, error) {
+	certId := cert.Id
+	start := time.Now()
+	lastStatus := ioriver.CertificateStatus("")
+
+	err := waitUntil(ctx, client, fmt.Sprintf("certificate %s to be issued", cert.Name), func() (bool, error) {
+		current, err := callIdempotent(ctx, client, func() (*ioriver.Certificate, error) { return client.GetCertificate(certId) })
+		if err != nil {
+			return false, err
+		}
+		cert = current
+
+		if cert.Status != lastStatus {
+			lastStatus = cert.Status
+			tflog.Info(ctx, fmt.Sprintf("Certificate %s status: %s, elapsed %s", certId, cert.Status, time.Since(start).Round(time.Second)))
+		}
+		if cert.Status == certificateStatusFailed {
+			return false, &StateError{Status: string(cert.Status), Reason: certificateStatusDetails(ctx, client, certId)}
+		}
+		return cert.Status == certificateStatusIssued, nil
+	})
+	return cert, err
+}
+
+// certificateStatusDetails returns the reason the backend reports for the status
+// of a certificate. ioriver-go doesn't decode it, so it is read with a raw call
+// of the same endpoint. Empty when it can't be read.
+func certificateStatusDetails(ctx context.Context, client *Client, certId string) string {
+	var details struct {
+		StatusDetails string `json:"status_details"`
+	}
+	if err := apiGet(ctx, client, "certificates/"+certId+"/", &details); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Failed to read the status details of certificate %s: %s", certId, err))
+		return ""
+	}
+	return details.StatusDetails
+}
+
+// Convert providers certificates API objects to a set of ProviderCertificateModel
+func providersCertificatesToSet(ctx context.Context, providersCertificates []ioriver.ProviderCertificate) (types.Set, error) {
+	modelProvidersCertificates := []attr.Value{}
+	for _, providerCert := range providersCertificates {
+		value := ProviderCertificateModel{
+			AccountProvider:       types.StringValue(providerCert.AccountProvider),
+			ProviderCertificateId: types.StringValue(providerCert.ProviderCertificateId),
+			NotValidAfter:         types.StringValue(providerCert.NotValidAfter),
+		}
+
+		objectValue, diags := types.ObjectValueFrom(ctx, value.AttributeTypes(), value)
+		if diags.HasError() {
+			return types.Set{}, fmt.Errorf("failed to set provider certificate object")
+		}
+		modelProvidersCertificates = append(modelProvidersCertificates, objectValue)
+	}
+	certsModelAttr := ProviderCertificateModel{}.AttributeTypes()
+	providersCertsValue, diags := types.SetValue(types.ObjectType{AttrTypes: certsModelAttr}, modelProvidersCertificates)
+	if diags.HasError() {
+		return types.Set{}, fmt.Errorf("failed to set providers certificates field")
+	}
+	return providersCertsValue, nil
+}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ioriver/ioriver-go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CertificateValidationResource{}
+
+func NewCertificateValidationResource() resource.Resource {
+	return &CertificateValidationResource{}
+}
+
+// CertificateValidationResource waits for a certificate to be issued. It doesn't
+// create any object on the backend, so it can depend on the DNS challenge records
+// of the certificate, which themselves depend on the certificate.
+type CertificateValidationResource struct {
+	client *Client
+}
+
+type CertificateValidationResourceModel struct {
+	Id                    types.String   `tfsdk:"id"`
+	Certificate           types.String   `tfsdk:"certificate"`
+	ValidationRecordFqdns types.Set      `tfsdk:"validation_record_fqdns"`
+	Status                types.String   `tfsdk:"status"`
+	NotValidAfter         types.String   `tfsdk:"not_valid_after"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *CertificateValidationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_validation"
+}
+
+func (r *CertificateValidationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Waits for a certificate to be issued, up to the create timeout. The apply fails with the reason reported by the backend " +
+			"if the certificate issuance fails. Resources which use the certificate can depend on it, so they are only created once the certificate is usable. " +
+			"No object is created on the backend, deleting it only removes it from the state",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The id of the validated certificate",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate": schema.StringAttribute{
+				MarkdownDescription: "The id of the certificate to wait for",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"validation_record_fqdns": schema.SetAttribute{
+				MarkdownDescription: "Names of the DNS records created for the `challenges_records` of the certificate. " +
+					"Only used to order the wait after the records are created",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Certificate status",
+				Computed:            true,
+			},
+			"not_valid_after": schema.StringAttribute{
+				MarkdownDescription: "Certificate expiration date",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// Configure resource and retrieve API client
+func (r *CertificateValidationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = ConfigureBase(ctx, req, resp)
+}
+
+// Create CertificateValidation resource
+func (r *CertificateValidationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CertificateValidationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	certId := data.Certificate.ValueString()
+	cert, err := callIdempotent(ctx, r.client, func() (*ioriver.Certificate, error) { return r.client.GetCertificate(certId) })
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.Plan.Schema, "Client Error", "Unable to read certificate", err)
+		return
+	}
+
+	// nothing is saved in state on failure, so the next apply waits again
+	cert, err = waitForCertificateIssued(ctx, r.client, cert)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("certificate"), "Certificate was not issued", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, certificateValidationFromObj(cert, data))...)
+}
+
+// Read CertificateValidation resource
+func (r *CertificateValidationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CertificateValidationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certId := data.Certificate.ValueString()
+	cert, err := callIdempotent(ctx, r.client, func() (*ioriver.Certificate, error) { return r.client.GetCertificate(certId) })
+	if err != nil {
+		if isNotFoundError(err) {
+			tflog.Info(ctx, fmt.Sprintf("Certificate %s not found", certId))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.State.Schema, "Client Error", "Unable to read certificate", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, certificateValidationFromObj(cert, data))...)
+}
+
+// Update CertificateValidation resource
+func (r *CertificateValidationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CertificateValidationResourceModel
+	var state CertificateValidationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// all other attributes require replacement, only the timeouts can change
+	data.Id = state.Id
+	data.Status = state.Status
+	data.NotValidAfter = state.NotValidAfter
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete CertificateValidation resource
+func (r *CertificateValidationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// no-op: nothing was created on the backend, Terraform will remove it from state automatically
+}
+
+// certificateValidationFromObj sets the attributes read from the certificate
+func certificateValidationFromObj(cert *ioriver.Certificate, data CertificateValidationResourceModel) *CertificateValidationResourceModel {
+	data.Id = types.StringValue(cert.Id)
+	data.Status = types.StringValue(string(cert.Status))
+	data.NotValidAfter = types.StringValue(cert.NotValidAfter)
+	return &data
+}
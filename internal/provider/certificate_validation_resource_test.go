@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIORiverCertificateValidation_Basic(t *testing.T) {
+	rndName := generateRandomResourceName()
+	certName := certResourceType + "." + rndName
+	resourceName := "ioriver_certificate_validation." + rndName
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCertificateValidationConfig(rndName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", certName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "status", string(certificateStatusIssued)),
+					resource.TestCheckResourceAttrPair(resourceName, "not_valid_after", certName, "not_valid_after"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCertificateValidationConfig(rndName string) string {
+	return testAccCheckCertificateConfig(rndName, rndName) + fmt.Sprintf(`
+
+resource "ioriver_certificate_validation" "%[1]s" {
+	certificate = ioriver_certificate.%[1]s.id
+}`, rndName)
+}
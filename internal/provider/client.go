@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"time"
+
+	ioriver "github.com/ioriver/ioriver-go"
+)
+
+// Client is the API client of a provider configuration, shared by its resources
+// and data sources. Every provider configuration (including aliases) creates its
+// own client, which carries the settings of that configuration next to the
+// ioriver-go client.
+type Client struct {
+	*ioriver.IORiverClient
+
+	// token is also used by the calls the ioriver-go client doesn't expose, see
+	// apiRequest
+	token        string
+	retryConfig  RetryConfig
+	pollInterval time.Duration
+}
+
+// NewClient returns a client of the given API endpoint, with the default retries
+// and poll interval. The default endpoint of ioriver-go is used when endpoint is
+// empty.
+func NewClient(endpoint string, token string, version string) *Client {
+	client := ioriver.NewClient(token)
+	if endpoint != "" {
+		client.EndpointUrl = endpoint
+	}
+	client.TerraformVersion = version
+
+	return &Client{
+		IORiverClient: client,
+		token:         token,
+		retryConfig:   defaultRetryConfig,
+		pollInterval:  DefaultPollInterval,
+	}
+}
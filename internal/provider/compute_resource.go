@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	ioriver "github.com/ioriver/ioriver-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -138,19 +137,19 @@ func (r *ComputeResource) ImportState(ctx context.Context, req resource.ImportSt
 
 // ------- Implement base Resource API ---------
 
-func (ComputeResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
+func (ComputeResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (ComputeResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
+func (ComputeResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (ComputeResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
+func (ComputeResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (ComputeResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
+func (ComputeResource) delete(ctx context.Context, client *Client, id interface{}) error {
 	return nil
 }
 
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Attributes identifying the elements of nested lists, e.g. behaviors by name
+// and domains by domain. Elements without one of them are matched by index.
+var listElementKeys = []string{"name", "domain"}
+
+// configDiffPaths returns readable paths of the values which differ between two
+// config values, e.g. behaviors.custom["static"].actions.cache_ttl. Added or
+// removed list elements and objects are reported by their own path.
+func configDiffPaths(before tftypes.Value, after tftypes.Value) []string {
+	paths := []string{}
+	diffValues("", before, after, &paths)
+	return paths
+}
+
+func diffValues(p string, before tftypes.Value, after tftypes.Value, paths *[]string) {
+	if before.Equal(after) {
+		return
+	}
+	if !before.IsKnown() || !after.IsKnown() || before.IsNull() || after.IsNull() || !before.Type().Equal(after.Type()) {
+		*paths = append(*paths, p)
+		return
+	}
+
+	switch {
+	case before.Type().Is(tftypes.Object{}) || before.Type().Is(tftypes.Map{}):
+		var beforeAttrs, afterAttrs map[string]tftypes.Value
+		if before.As(&beforeAttrs) != nil || after.As(&afterAttrs) != nil {
+			*paths = append(*paths, p)
+			return
+		}
+		names := make([]string, 0, len(afterAttrs))
+		for name := range afterAttrs {
+			names = append(names, name)
+		}
+		for name := range beforeAttrs {
+			if _, ok := afterAttrs[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			childPath := joinPath(p, name)
+			if before.Type().Is(tftypes.Map{}) {
+				childPath = fmt.Sprintf("%s[%q]", p, name)
+			}
+			beforeAttr, beforeOk := beforeAttrs[name]
+			afterAttr, afterOk := afterAttrs[name]
+			if !beforeOk || !afterOk {
+				*paths = append(*paths, childPath)
+				continue
+			}
+			diffValues(childPath, beforeAttr, afterAttr, paths)
+		}
+	case before.Type().Is(tftypes.List{}) || before.Type().Is(tftypes.Set{}) || before.Type().Is(tftypes.Tuple{}):
+		var beforeElems, afterElems []tftypes.Value
+		if before.As(&beforeElems) != nil || after.As(&afterElems) != nil {
+			*paths = append(*paths, p)
+			return
+		}
+		diffElements(p, beforeElems, afterElems, paths)
+	default:
+		*paths = append(*paths, p)
+	}
+}
+
+// diffElements matches list elements by their key attribute, or by index when the
+// elements have no key
+func diffElements(p string, before []tftypes.Value, after []tftypes.Value, paths *[]string) {
+	beforeKeys := elementKeys(before)
+	afterKeys := elementKeys(after)
+	if beforeKeys == nil || afterKeys == nil {
+		for i := 0; i < len(before) || i < len(after); i++ {
+			elemPath := fmt.Sprintf("%s[%d]", p, i)
+			if i >= len(before) || i >= len(after) {
+				*paths = append(*paths, elemPath)
+				continue
+			}
+			diffValues(elemPath, before[i], after[i], paths)
+		}
+		return
+	}
+
+	beforeByKey := make(map[string]tftypes.Value, len(before))
+	for i, key := range beforeKeys {
+		beforeByKey[key] = before[i]
+	}
+	afterByKey := make(map[string]bool, len(after))
+	for i, key := range afterKeys {
+		afterByKey[key] = true
+		elemPath := fmt.Sprintf("%s[%q]", p, key)
+		beforeElem, ok := beforeByKey[key]
+		if !ok {
+			*paths = append(*paths, elemPath)
+			continue
+		}
+		diffValues(elemPath, beforeElem, after[i], paths)
+	}
+	for _, key := range beforeKeys {
+		if !afterByKey[key] {
+			*paths = append(*paths, fmt.Sprintf("%s[%q]", p, key))
+		}
+	}
+}
+
+// elementKeys returns the key of each element, or nil if the elements can't be
+// identified by a unique key
+func elementKeys(elems []tftypes.Value) []string {
+	for _, keyName := range listElementKeys {
+		keys := make([]string, 0, len(elems))
+		seen := map[string]bool{}
+		for _, elem := range elems {
+			var attrs map[string]tftypes.Value
+			if !elem.Type().Is(tftypes.Object{}) || elem.As(&attrs) != nil {
+				return nil
+			}
+			var key string
+			keyValue, ok := attrs[keyName]
+			if !ok || !keyValue.IsKnown() || keyValue.IsNull() || keyValue.As(&key) != nil || seen[key] {
+				keys = nil
+				break
+			}
+			seen[key] = true
+			keys = append(keys, key)
+		}
+		if keys != nil {
+			return keys
+		}
+	}
+	return nil
+}
+
+func joinPath(p string, name string) string {
+	if p == "" {
+		return name
+	}
+	return p + "." + name
+}
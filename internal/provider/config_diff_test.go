@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
+)
+
+var testBehaviorType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"name":      tftypes.String,
+	"cache_ttl": tftypes.Number,
+}}
+
+func testBehaviorsValue(behaviors map[string]int) tftypes.Value {
+	elems := []tftypes.Value{}
+	for _, name := range []string{"images", "static", "api"} {
+		ttl, ok := behaviors[name]
+		if !ok {
+			continue
+		}
+		elems = append(elems, tftypes.NewValue(testBehaviorType, map[string]tftypes.Value{
+			"name":      tftypes.NewValue(tftypes.String, name),
+			"cache_ttl": tftypes.NewValue(tftypes.Number, ttl),
+		}))
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: testBehaviorType}, elems)
+}
+
+func TestConfigDiffPaths(t *testing.T) {
+	domainsType := tftypes.List{ElementType: tftypes.String}
+	configType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"domains": domainsType,
+		"custom":  tftypes.List{ElementType: testBehaviorType},
+	}}
+	config := func(domains []string, behaviors map[string]int) tftypes.Value {
+		domainValues := []tftypes.Value{}
+		for _, domain := range domains {
+			domainValues = append(domainValues, tftypes.NewValue(tftypes.String, domain))
+		}
+		return tftypes.NewValue(configType, map[string]tftypes.Value{
+			"domains": tftypes.NewValue(domainsType, domainValues),
+			"custom":  testBehaviorsValue(behaviors),
+		})
+	}
+
+	before := config([]string{"a.example.com"}, map[string]int{"static": 60, "images": 60})
+	cases := []struct {
+		after    tftypes.Value
+		expected []string
+	}{
+		{before, []string{}},
+		{config([]string{"a.example.com"}, map[string]int{"static": 120, "images": 60}), []string{`custom["static"].cache_ttl`}},
+		{config([]string{"a.example.com"}, map[string]int{"static": 60, "api": 0}), []string{`custom["api"]`, `custom["images"]`}},
+		{config([]string{"b.example.com", "c.example.com"}, map[string]int{"static": 60, "images": 60}), []string{"domains[0]", "domains[1]"}},
+		{tftypes.NewValue(configType, nil), []string{""}},
+	}
+	for _, c := range cases {
+		paths := configDiffPaths(before, c.after)
+		if fmt.Sprint(paths) != fmt.Sprint(c.expected) {
+			t.Errorf("expected %v, got %v", c.expected, paths)
+		}
+	}
+}
+
+func TestReportConfigDrift(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	serviceId := server.AddService("svc", "cert-id", map[string]interface{}{})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	// the config is changed outside Terraform
+	service, err := GetServiceWithConfig(ctx, client, serviceId)
+	if err != nil {
+		t.Fatalf("failed to read service: %s", err)
+	}
+	service.Description = "UI change"
+	if _, err := UpdateServiceWithConfig(ctx, client, *service); err != nil {
+		t.Fatalf("failed to update service: %s", err)
+	}
+
+	testSchema := schema.Schema{Attributes: map[string]schema.Attribute{
+		"id": schema.StringAttribute{Computed: true},
+		"config": schema.SingleNestedAttribute{Required: true, Attributes: map[string]schema.Attribute{
+			"custom": schema.ListNestedAttribute{Optional: true, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name":      schema.StringAttribute{Required: true},
+					"cache_ttl": schema.NumberAttribute{Optional: true},
+				},
+			}},
+		}},
+	}}
+	state := func(behaviors map[string]int) tfsdk.State {
+		stateType := testSchema.Type().TerraformType(ctx).(tftypes.Object)
+		return tfsdk.State{Schema: testSchema, Raw: tftypes.NewValue(stateType, map[string]tftypes.Value{
+			"id": tftypes.NewValue(tftypes.String, serviceId),
+			"config": tftypes.NewValue(stateType.AttributeTypes["config"], map[string]tftypes.Value{
+				"custom": testBehaviorsValue(behaviors),
+			}),
+		})}
+	}
+
+	r := &ServiceResource{client: client}
+	req := resource.ReadRequest{State: state(map[string]int{"static": 60})}
+
+	// differences without a new config version are not reported
+	resp := resource.ReadResponse{State: state(map[string]int{"static": 120})}
+	r.reportConfigDrift(ctx, req, &resp, 2, 2)
+	if len(resp.Diagnostics) != 0 {
+		t.Fatalf("expected no diagnostics, got %v", resp.Diagnostics)
+	}
+
+	r.reportConfigDrift(ctx, req, &resp, 1, 2)
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a drift warning, got %v", resp.Diagnostics)
+	}
+	detail := resp.Diagnostics.Warnings()[0].Detail()
+	for _, expected := range []string{"from version 1 to version 2", "version 2 by " + mockapi.MockUser, `"UI change"`, `custom["static"].cache_ttl`} {
+		if !strings.Contains(detail, expected) {
+			t.Errorf("expected the warning to contain %q, got:\n%s", expected, detail)
+		}
+	}
+}
@@ -5,7 +5,8 @@ const (
 
 	TerraformUserAgent = "terraform/%s terraform-plugin-framework/%s terraform-provider-ioriver/%s"
 
-	APIEndpointEnvVar = "IORIVER_API_ENDPOINT"
+	APIEndpointEnvVar  = "IORIVER_API_ENDPOINT"
+	APIDefaultEndpoint = "https://manage.ioriver.io/api/"
 
 	APITokenSchemaKey = "token"
 	APITokenEvnVar    = "IORIVER_API_TOKEN"
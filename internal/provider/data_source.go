@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+func ConfigureDataSourceBase(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) *Client {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	client, ok := req.ProviderData.(*Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return nil
+	}
+
+	return client
+}
+
+// computedAttributes converts resource schema attributes into computed data source
+// attributes of the same type. This lets data sources expose objects, such as the
+// service config, with the same models used by the matching resources.
+func computedAttributes(attributes map[string]rschema.Attribute) map[string]dschema.Attribute {
+	result := make(map[string]dschema.Attribute, len(attributes))
+	for name, attribute := range attributes {
+		result[name] = computedAttribute(attribute)
+	}
+	return result
+}
+
+func computedAttribute(attribute rschema.Attribute) dschema.Attribute {
+	description := attribute.GetMarkdownDescription()
+	sensitive := attribute.IsSensitive()
+
+	switch a := attribute.(type) {
+	case rschema.StringAttribute:
+		return dschema.StringAttribute{MarkdownDescription: description, Computed: true, Sensitive: sensitive, CustomType: a.CustomType}
+	case rschema.BoolAttribute:
+		return dschema.BoolAttribute{MarkdownDescription: description, Computed: true, Sensitive: sensitive, CustomType: a.CustomType}
+	case rschema.Int64Attribute:
+		return dschema.Int64Attribute{MarkdownDescription: description, Computed: true, Sensitive: sensitive, CustomType: a.CustomType}
+	case rschema.Float64Attribute:
+		return dschema.Float64Attribute{MarkdownDescription: description, Computed: true, Sensitive: sensitive, CustomType: a.CustomType}
+	case rschema.ListAttribute:
+		return dschema.ListAttribute{MarkdownDescription: description, Computed: true, Sensitive: sensitive, ElementType: a.ElementType, CustomType: a.CustomType}
+	case rschema.SetAttribute:
+		return dschema.SetAttribute{MarkdownDescription: description, Computed: true, Sensitive: sensitive, ElementType: a.ElementType, CustomType: a.CustomType}
+	case rschema.MapAttribute:
+		return dschema.MapAttribute{MarkdownDescription: description, Computed: true, Sensitive: sensitive, ElementType: a.ElementType, CustomType: a.CustomType}
+	case rschema.ObjectAttribute:
+		return dschema.ObjectAttribute{MarkdownDescription: description, Computed: true, Sensitive: sensitive, AttributeTypes: a.AttributeTypes, CustomType: a.CustomType}
+	case rschema.SingleNestedAttribute:
+		return dschema.SingleNestedAttribute{MarkdownDescription: description, Computed: true, Sensitive: sensitive,
+			Attributes: computedAttributes(a.Attributes), CustomType: a.CustomType}
+	case rschema.ListNestedAttribute:
+		return dschema.ListNestedAttribute{MarkdownDescription: description, Computed: true, Sensitive: sensitive,
+			NestedObject: dschema.NestedAttributeObject{Attributes: computedAttributes(a.NestedObject.Attributes), CustomType: a.NestedObject.CustomType},
+			CustomType:   a.CustomType}
+	case rschema.SetNestedAttribute:
+		return dschema.SetNestedAttribute{MarkdownDescription: description, Computed: true, Sensitive: sensitive,
+			NestedObject: dschema.NestedAttributeObject{Attributes: computedAttributes(a.NestedObject.Attributes), CustomType: a.NestedObject.CustomType},
+			CustomType:   a.CustomType}
+	case rschema.MapNestedAttribute:
+		return dschema.MapNestedAttribute{MarkdownDescription: description, Computed: true, Sensitive: sensitive,
+			NestedObject: dschema.NestedAttributeObject{Attributes: computedAttributes(a.NestedObject.Attributes), CustomType: a.NestedObject.CustomType},
+			CustomType:   a.CustomType}
+	}
+
+	panic(fmt.Sprintf("unsupported schema attribute type %T", attribute))
+}
@@ -22,10 +22,11 @@ type DomainMappingModelV1 struct {
 }
 
 type DomainModel struct {
-	UUId     types.String           `tfsdk:"uuid"`
-	Domain   types.String           `tfsdk:"domain"`
-	Aliases  types.List             `tfsdk:"aliases"`
-	Mappings []DomainMappingModelV1 `tfsdk:"mappings"`
+	UUId        types.String           `tfsdk:"uuid"`
+	Domain      types.String           `tfsdk:"domain"`
+	Aliases     types.List             `tfsdk:"aliases"`
+	Certificate types.String           `tfsdk:"certificate"`
+	Mappings    []DomainMappingModelV1 `tfsdk:"mappings"`
 }
 
 func (d DomainModel) GetName() string {
@@ -46,10 +47,11 @@ func DomainMappingAttrTypes() map[string]attr.Type {
 
 func DomainAttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"uuid":     types.StringType,
-		"domain":   types.StringType,
-		"aliases":  types.ListType{ElemType: types.StringType},
-		"mappings": types.ListType{ElemType: types.ObjectType{AttrTypes: DomainMappingAttrTypes()}},
+		"uuid":        types.StringType,
+		"domain":      types.StringType,
+		"aliases":     types.ListType{ElemType: types.StringType},
+		"certificate": types.StringType,
+		"mappings":    types.ListType{ElemType: types.ObjectType{AttrTypes: DomainMappingAttrTypes()}},
 	}
 }
 
@@ -77,6 +79,11 @@ func DomainAttributes() map[string]schema.Attribute {
 				ListNullClearsStateModifier(),
 			},
 		},
+		"certificate": schema.StringAttribute{
+			MarkdownDescription: "ID of the certificate covering the domain and its aliases, one of the certificates of the service. " +
+				"Without it, the domain may be covered by any of the certificates of the service",
+			Optional: true,
+		},
 		"mappings": schema.ListNestedAttribute{
 			MarkdownDescription: "A list of mappings between path pattern and target.\n" +
 				"  - Order of paths are performed by ioriver internally.",
@@ -166,6 +173,10 @@ func (d *DomainModel) ModelToMap(ctx context.Context, originNamesToUUIDs map[str
 	// Always include aliases (even if empty array)
 	domainMap["aliases"] = aliasesArray
 
+	if !d.Certificate.IsNull() && !d.Certificate.IsUnknown() {
+		domainMap["certificate"] = d.Certificate.ValueString()
+	}
+
 	// convert mappings
 	mappingsArray := make([]interface{}, 0)
 	for _, mapping := range d.Mappings {
@@ -275,6 +286,12 @@ func domainFromMap(ctx context.Context, domainMap map[string]interface{}, uuidTo
 		domainModel.Aliases = aliasListValue
 	}
 
+	// Convert certificate
+	domainModel.Certificate = types.StringNull()
+	if certificate, ok := domainMap["certificate"].(string); ok && certificate != "" {
+		domainModel.Certificate = types.StringValue(certificate)
+	}
+
 	// Convert mappings
 	if mappings, ok := domainMap["mappings"].([]interface{}); ok {
 		for _, mapping := range mappings {
@@ -12,7 +12,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	ioriver "github.com/ioriver/ioriver-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -207,19 +206,19 @@ func (r *DomainResource) UpgradeState(ctx context.Context) map[int64]resource.St
 
 // ------- Implement base Resource API ---------
 
-func (DomainResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
+func (DomainResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (DomainResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
+func (DomainResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (DomainResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
+func (DomainResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (DomainResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
+func (DomainResource) delete(ctx context.Context, client *Client, id interface{}) error {
 	return nil
 }
 
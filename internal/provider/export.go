@@ -0,0 +1,522 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+
+	ioriver "github.com/ioriver/ioriver-go"
+)
+
+// ExportOptions selects the API and the services written by Export
+type ExportOptions struct {
+	// API endpoint and token, IORIVER_API_ENDPOINT and IORIVER_API_TOKEN when empty
+	Endpoint string
+	Token    string
+	// provider version, sent in the user agent
+	Version string
+	// only services whose name matches both filters are exported, when set
+	NamePrefix string
+	NameRegex  string
+}
+
+// Export writes the services of the account as Terraform configuration, together
+// with their service providers, health and performance monitors and traffic
+// policies. Every resource is followed by an import block, so applying the
+// configuration adopts the existing objects instead of creating new ones.
+//
+// Resources are written ordered by service name, and named after the service and
+// the object, so exporting the same account again gives the same output.
+// Write-only credentials are not returned by the API and are not exported.
+func Export(ctx context.Context, opts ExportOptions, w io.Writer) error {
+	client := newExportClient(opts)
+
+	filters := ServicesDataSourceModel{NamePrefix: types.StringNull(), NameRegex: types.StringNull(), Certificate: types.StringNull()}
+	if opts.NamePrefix != "" {
+		filters.NamePrefix = types.StringValue(opts.NamePrefix)
+	}
+	if opts.NameRegex != "" {
+		filters.NameRegex = types.StringValue(opts.NameRegex)
+	}
+
+	services, err := ListServicesWithConfig(ctx, client)
+	if err != nil {
+		return fmt.Errorf("unable to list services: %w", err)
+	}
+	if services, err = filterServices(services, filters); err != nil {
+		return fmt.Errorf("invalid name regex: %w", err)
+	}
+	if services, err = loadServicesConfig(ctx, client, services); err != nil {
+		return fmt.Errorf("unable to read service config: %w", err)
+	}
+
+	e := newExporter(ctx, client)
+	for i := range services {
+		if err := e.exportService(&services[i]); err != nil {
+			return fmt.Errorf("service %s: %w", services[i].Name, err)
+		}
+	}
+
+	_, err = w.Write(hclwrite.Format(e.file.Bytes()))
+	return err
+}
+
+// newExportClient returns a client configured like the one of the provider, with
+// the default retries
+func newExportClient(opts ExportOptions) *Client {
+	endpoint := opts.Endpoint
+	if endpoint == "" {
+		endpoint = os.Getenv(APIEndpointEnvVar)
+	}
+	if endpoint == "" {
+		endpoint = APIDefaultEndpoint
+	}
+	token := opts.Token
+	if token == "" {
+		token = os.Getenv(APITokenEvnVar)
+	}
+	return NewClient(endpoint, token, opts.Version)
+}
+
+type exporter struct {
+	ctx    context.Context
+	client *Client
+	file   *hclwrite.File
+	// resource names already used, per resource type
+	names map[string]map[string]bool
+}
+
+func newExporter(ctx context.Context, client *Client) *exporter {
+	return &exporter{ctx: ctx, client: client, file: hclwrite.NewEmptyFile(), names: map[string]map[string]bool{}}
+}
+
+// exportService writes a service and the objects which belong to it. The objects
+// refer to the service, and traffic policies to the service providers and
+// monitors, through resource references rather than IDs.
+func (e *exporter) exportService(service *ServiceWithConfig) error {
+	r := &ServiceResource{}
+	s := resourceSchema(e.ctx, r)
+	data, err := exportModel[ServiceResourceModel](e.ctx, s)
+	if err != nil {
+		return err
+	}
+	// same as import: no prior state nor transform context
+	data.updateTransformCtx = &ServiceTransformContext{
+		OriginNamesToUUIDs:  make(map[string]string),
+		DesiredOriginOrder:  []string{},
+		LogDestNamesToUUIDs: make(map[string]string),
+		DesiredLogDestOrder: []string{},
+	}
+	serviceName := exportResourceName(service.Name)
+	serviceRef, err := e.writeResource(r, s, serviceName, service.Id, service, data, nil)
+	if err != nil {
+		return err
+	}
+	refs := map[string]hcl.Traversal{service.Id: serviceRef}
+
+	serviceProviders, err := callIdempotent(e.ctx, e.client, func() ([]ioriver.ServiceProvider, error) {
+		return e.client.ListServiceProviders(service.Id)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list service providers: %w", err)
+	}
+	sortByName(serviceProviders, func(p ioriver.ServiceProvider) (string, string) {
+		return serviceProviderExportName(p), p.Id
+	})
+	for i := range serviceProviders {
+		p := &serviceProviders[i]
+		if err := exportServiceObject[ServiceProviderResourceModel](e, &ServiceProviderResource{}, serviceName, serviceProviderExportName(*p), service.Id, p.Id, p, refs); err != nil {
+			return err
+		}
+	}
+
+	healthMonitors, err := callIdempotent(e.ctx, e.client, func() ([]ioriver.HealthMonitor, error) {
+		return e.client.ListHealthMonitors(service.Id)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list health monitors: %w", err)
+	}
+	sortByName(healthMonitors, func(m ioriver.HealthMonitor) (string, string) { return m.Name, m.Id })
+	for i := range healthMonitors {
+		m := &healthMonitors[i]
+		if err := exportServiceObject[HealthMonitorResourceModel](e, &HealthMonitorResource{}, serviceName, m.Name, service.Id, m.Id, m, refs); err != nil {
+			return err
+		}
+	}
+
+	performanceMonitors, err := callIdempotent(e.ctx, e.client, func() ([]ioriver.PerformanceMonitor, error) {
+		return e.client.ListPerformanceMonitors(service.Id)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list performance monitors: %w", err)
+	}
+	sortByName(performanceMonitors, func(m ioriver.PerformanceMonitor) (string, string) { return m.Name, m.Id })
+	for i := range performanceMonitors {
+		m := &performanceMonitors[i]
+		if err := exportServiceObject[PerformanceMonitorResourceModel](e, &PerformanceMonitorResource{}, serviceName, m.Name, service.Id, m.Id, m, refs); err != nil {
+			return err
+		}
+	}
+
+	trafficPolicies, err := callIdempotent(e.ctx, e.client, func() ([]ioriver.TrafficPolicy, error) {
+		return e.client.ListTrafficPolicies(service.Id)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to list traffic policies: %w", err)
+	}
+	sortByName(trafficPolicies, func(p ioriver.TrafficPolicy) (string, string) { return trafficPolicyExportName(p), p.Id })
+	for i := range trafficPolicies {
+		p := &trafficPolicies[i]
+		if err := exportServiceObject[TrafficPolicyResourceModel](e, &TrafficPolicyResource{}, serviceName, trafficPolicyExportName(*p), service.Id, p.Id, p, refs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportServiceObject writes an object which belongs to a service. Such objects
+// are imported with service-id,id.
+func exportServiceObject[M any](e *exporter, r exportableResource, serviceName string, name string, serviceId string, id string, obj interface{}, refs map[string]hcl.Traversal) error {
+	s := resourceSchema(e.ctx, r)
+	data, err := exportModel[M](e.ctx, s)
+	if err != nil {
+		return err
+	}
+
+	ref, err := e.writeResource(r, s, exportResourceName(serviceName, name), serviceId+","+id, obj, data, refs)
+	if err != nil {
+		return err
+	}
+	refs[id] = ref
+	return nil
+}
+
+type exportableResource interface {
+	resource.Resource
+	Resource
+}
+
+// writeResource writes the configurable attributes of an API object followed by
+// its import block, and returns the reference to the written resource. String
+// values which are the ID of a resource in refs are written as references.
+func (e *exporter) writeResource(r exportableResource, s schema.Schema, name string, importId string, obj interface{}, data interface{}, refs map[string]hcl.Traversal) (hcl.Traversal, error) {
+	metadata := resource.MetadataResponse{}
+	r.Metadata(e.ctx, resource.MetadataRequest{ProviderTypeName: "ioriver"}, &metadata)
+	name = e.uniqueName(metadata.TypeName, name)
+
+	model, err := r.objToResource(e.ctx, obj, data)
+	if err != nil {
+		return nil, err
+	}
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(e.ctx), nil)}
+	if diags := state.Set(e.ctx, model); diags.HasError() {
+		return nil, fmt.Errorf("failed to convert %s %s: %v", metadata.TypeName, name, diags.Errors())
+	}
+
+	attributes := map[string]exportAttribute{}
+	for attrName, attribute := range s.Attributes {
+		attributes[attrName] = attribute
+	}
+	values := map[string]tftypes.Value{}
+	if err := state.Raw.As(&values); err != nil {
+		return nil, err
+	}
+
+	body := e.file.Body()
+	block := body.AppendNewBlock("resource", []string{metadata.TypeName, name})
+	for _, attrName := range exportedAttributeNames(attributes, values) {
+		tokens, err := exportTokens(attributes[attrName], values[attrName], refs)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", metadata.TypeName, attrName, err)
+		}
+		block.Body().SetAttributeRaw(attrName, tokens)
+	}
+	body.AppendNewline()
+
+	ref := hcl.Traversal{hcl.TraverseRoot{Name: metadata.TypeName}, hcl.TraverseAttr{Name: name}}
+	importBlock := body.AppendNewBlock("import", nil)
+	importBlock.Body().SetAttributeTraversal("to", ref)
+	importBlock.Body().SetAttributeValue("id", cty.StringVal(importId))
+	body.AppendNewline()
+
+	return append(ref, hcl.TraverseAttr{Name: "id"}), nil
+}
+
+// uniqueName returns name, with a numeric suffix when a resource of the same type
+// already has this name
+func (e *exporter) uniqueName(typeName string, name string) string {
+	if e.names[typeName] == nil {
+		e.names[typeName] = map[string]bool{}
+	}
+	unique := name
+	for i := 2; e.names[typeName][unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	e.names[typeName][unique] = true
+	return unique
+}
+
+func resourceSchema(ctx context.Context, r resource.Resource) schema.Schema {
+	resp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+	return resp.Schema
+}
+
+// exportModel returns a model with all attributes null, as the prior model of an
+// imported resource
+func exportModel[T any](ctx context.Context, s schema.Schema) (T, error) {
+	var data T
+	objectType := s.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(objectType, values)}
+	if diags := state.Get(ctx, &data); diags.HasError() {
+		return data, fmt.Errorf("failed to create model: %v", diags.Errors())
+	}
+	return data, nil
+}
+
+// sortByName orders API objects by name, then by ID for objects with the same name
+func sortByName[T any](objects []T, key func(T) (string, string)) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		iName, iId := key(objects[i])
+		jName, jId := key(objects[j])
+		if iName != jName {
+			return iName < jName
+		}
+		return iId < jId
+	})
+}
+
+func serviceProviderExportName(p ioriver.ServiceProvider) string {
+	if p.DisplayName != "" {
+		return p.DisplayName
+	}
+	return p.Name
+}
+
+// traffic policies have no name, they are named after their type
+func trafficPolicyExportName(p ioriver.TrafficPolicy) string {
+	if p.IsDefault {
+		return "default"
+	}
+	return strings.ToLower(string(p.Type))
+}
+
+var invalidResourceNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// exportResourceName returns a valid Terraform resource name made of the given
+// names, e.g. "www_example_com_fastly" for www.example.com and Fastly
+func exportResourceName(names ...string) string {
+	parts := []string{}
+	for _, name := range names {
+		part := invalidResourceNameChars.ReplaceAllString(strings.ToLower(name), "_")
+		if part = strings.Trim(part, "_"); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	name := strings.Join(parts, "_")
+	// names must start with a letter or an underscore
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// ---------------------------------------------------------------------------
+// HCL generation. Attributes are written in name order, and only when they can
+// be set in the configuration: computed-only attributes, null values and
+// write-only attributes are left out.
+// ---------------------------------------------------------------------------
+
+// exportAttribute is the part of a schema attribute used by the export
+type exportAttribute interface {
+	IsComputed() bool
+	IsOptional() bool
+	IsRequired() bool
+	IsWriteOnly() bool
+}
+
+// exportedAttributeNames returns the sorted names of the attributes to write
+func exportedAttributeNames(attributes map[string]exportAttribute, values map[string]tftypes.Value) []string {
+	names := []string{}
+	for name, attribute := range attributes {
+		value, ok := values[name]
+		if !ok || value.IsNull() || !value.IsKnown() || attribute.IsWriteOnly() {
+			continue
+		}
+		if attribute.IsRequired() || attribute.IsOptional() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// nestedAttributes returns the attributes of the objects of a nested attribute,
+// nil for other attributes
+func nestedAttributes(attribute exportAttribute) map[string]exportAttribute {
+	nested, ok := attribute.(schema.NestedAttribute)
+	if !ok {
+		return nil
+	}
+	attributes := map[string]exportAttribute{}
+	for name, a := range nested.GetNestedObject().GetAttributes() {
+		attributes[name] = a
+	}
+	return attributes
+}
+
+func exportTokens(attribute exportAttribute, value tftypes.Value, refs map[string]hcl.Traversal) (hclwrite.Tokens, error) {
+	attributes := nestedAttributes(attribute)
+	if attributes == nil {
+		return valueTokens(value, refs)
+	}
+
+	switch {
+	case value.Type().Is(tftypes.Object{}):
+		return objectTokens(attributes, value, refs)
+	case value.Type().Is(tftypes.List{}) || value.Type().Is(tftypes.Set{}):
+		elements := []tftypes.Value{}
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		tokens := []hclwrite.Tokens{}
+		for _, element := range elements {
+			t, err := objectTokens(attributes, element, refs)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+		}
+		return hclwrite.TokensForTuple(tokens), nil
+	case value.Type().Is(tftypes.Map{}):
+		elements := map[string]tftypes.Value{}
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		items := []hclwrite.ObjectAttrTokens{}
+		for _, key := range sortedKeys(elements) {
+			t, err := objectTokens(attributes, elements[key], refs)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, hclwrite.ObjectAttrTokens{Name: keyTokens(key), Value: t})
+		}
+		return hclwrite.TokensForObject(items), nil
+	}
+	return nil, fmt.Errorf("unsupported nested value %s", value.Type())
+}
+
+func objectTokens(attributes map[string]exportAttribute, value tftypes.Value, refs map[string]hcl.Traversal) (hclwrite.Tokens, error) {
+	values := map[string]tftypes.Value{}
+	if err := value.As(&values); err != nil {
+		return nil, err
+	}
+	items := []hclwrite.ObjectAttrTokens{}
+	for _, name := range exportedAttributeNames(attributes, values) {
+		t, err := exportTokens(attributes[name], values[name], refs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		items = append(items, hclwrite.ObjectAttrTokens{Name: hclwrite.TokensForIdentifier(name), Value: t})
+	}
+	return hclwrite.TokensForObject(items), nil
+}
+
+// valueTokens returns the tokens of a value of an attribute which is not nested
+func valueTokens(value tftypes.Value, refs map[string]hcl.Traversal) (hclwrite.Tokens, error) {
+	if value.IsNull() {
+		return hclwrite.TokensForValue(cty.NullVal(cty.DynamicPseudoType)), nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var s string
+		if err := value.As(&s); err != nil {
+			return nil, err
+		}
+		if ref, ok := refs[s]; ok {
+			return hclwrite.TokensForTraversal(ref), nil
+		}
+		return hclwrite.TokensForValue(cty.StringVal(s)), nil
+	case value.Type().Is(tftypes.Number):
+		n := new(big.Float)
+		if err := value.As(&n); err != nil {
+			return nil, err
+		}
+		return hclwrite.TokensForValue(cty.NumberVal(n)), nil
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+		if err := value.As(&b); err != nil {
+			return nil, err
+		}
+		return hclwrite.TokensForValue(cty.BoolVal(b)), nil
+	case value.Type().Is(tftypes.List{}) || value.Type().Is(tftypes.Set{}) || value.Type().Is(tftypes.Tuple{}):
+		elements := []tftypes.Value{}
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		tokens := []hclwrite.Tokens{}
+		for _, element := range elements {
+			t, err := valueTokens(element, refs)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, t)
+		}
+		return hclwrite.TokensForTuple(tokens), nil
+	case value.Type().Is(tftypes.Map{}) || value.Type().Is(tftypes.Object{}):
+		elements := map[string]tftypes.Value{}
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		items := []hclwrite.ObjectAttrTokens{}
+		for _, key := range sortedKeys(elements) {
+			if elements[key].IsNull() {
+				continue
+			}
+			t, err := valueTokens(elements[key], refs)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, hclwrite.ObjectAttrTokens{Name: keyTokens(key), Value: t})
+		}
+		return hclwrite.TokensForObject(items), nil
+	}
+	return nil, fmt.Errorf("unsupported value %s", value.Type())
+}
+
+// keyTokens returns the tokens of an object key, quoted when it is not an identifier
+func keyTokens(key string) hclwrite.Tokens {
+	if hclsyntax.ValidIdentifier(key) {
+		return hclwrite.TokensForIdentifier(key)
+	}
+	return hclwrite.TokensForValue(cty.StringVal(key))
+}
+
+func sortedKeys(values map[string]tftypes.Value) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
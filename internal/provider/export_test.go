@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ioriver "github.com/ioriver/ioriver-go"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
+)
+
+var spacesPattern = regexp.MustCompile(` +`)
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	emptyConfig := func() map[string]interface{} {
+		return map[string]interface{}{"domains": []interface{}{}, "origins": []interface{}{}}
+	}
+	server.AddService("www.example.com", "cert-2", emptyConfig())
+	serviceId := server.AddService("api.example.com", "cert-1", emptyConfig())
+	server.AddService("other", "cert-3", emptyConfig())
+
+	fastly := server.AddServiceObject(serviceId, "service-providers", map[string]interface{}{
+		"account_provider": "account-provider-id",
+		"name":             "fastly",
+		"display_name":     "Fastly",
+	})
+	server.AddServiceObject(serviceId, "service-providers", map[string]interface{}{
+		"account_provider": "account-provider-id-2",
+		"name":             "cloudfront",
+		"display_name":     "CloudFront",
+	})
+	healthMonitor := server.AddServiceObject(serviceId, "health-checks", map[string]interface{}{
+		"name": "Home page", "url": "https://api.example.com/", "enabled": true,
+	})
+	server.AddServiceObject(serviceId, "performance-checks", map[string]interface{}{
+		"name": "Home page", "url": "https://api.example.com/", "enabled": false,
+	})
+	server.AddServiceObject(serviceId, "traffic-policies", map[string]interface{}{
+		"type":          ioriver.TRAFFIC_POLICY_STATIC,
+		"failover":      true,
+		"is_default":    true,
+		"providers":     []map[string]interface{}{{"service_provider": fastly, "weight": 100}},
+		"geos":          []map[string]interface{}{},
+		"health_checks": []map[string]interface{}{{"health_check": healthMonitor}},
+	})
+
+	opts := ExportOptions{Endpoint: server.Endpoint(), Token: "test", NameRegex: "example"}
+	var out bytes.Buffer
+	if err := Export(ctx, opts, &out); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	exported := out.String()
+
+	if _, diags := hclsyntax.ParseConfig(out.Bytes(), "export.tf", hcl.InitialPos); diags.HasErrors() {
+		t.Fatalf("exported configuration is invalid: %s\n%s", diags.Error(), exported)
+	}
+
+	// services by name, then the objects of each service
+	expectedOrder := []string{
+		`resource "ioriver_service" "api_example_com"`,
+		`to = ioriver_service.api_example_com`,
+		`resource "ioriver_service_provider" "api_example_com_cloudfront"`,
+		`resource "ioriver_service_provider" "api_example_com_fastly"`,
+		`id = "` + serviceId + `,` + fastly + `"`,
+		`resource "ioriver_health_monitor" "api_example_com_home_page"`,
+		`resource "ioriver_performance_monitor" "api_example_com_home_page"`,
+		`resource "ioriver_traffic_policy" "api_example_com_default"`,
+		`resource "ioriver_service" "www_example_com"`,
+	}
+	last := -1
+	for _, expected := range expectedOrder {
+		i := strings.Index(exported, expected)
+		if i <= last {
+			t.Fatalf("expected %q after the previous resources, got:\n%s", expected, exported)
+		}
+		last = i
+	}
+
+	for _, expected := range []string{
+		`certificate = "cert-1"`,
+		`service = ioriver_service.api_example_com.id`,
+		`service_provider = ioriver_service_provider.api_example_com_fastly.id`,
+		`health_monitor = ioriver_health_monitor.api_example_com_home_page.id`,
+	} {
+		// attributes are aligned, compare without the alignment
+		if !strings.Contains(spacesPattern.ReplaceAllString(exported, " "), expected) {
+			t.Fatalf("expected %q in:\n%s", expected, exported)
+		}
+	}
+	// computed-only attributes are left out
+	for _, unexpected := range []string{"other", "uuid", "status", "is_failed"} {
+		if strings.Contains(exported, unexpected) {
+			t.Fatalf("unexpected %q in:\n%s", unexpected, exported)
+		}
+	}
+
+	// exporting again gives the same configuration
+	var again bytes.Buffer
+	if err := Export(ctx, opts, &again); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if again.String() != exported {
+		t.Fatalf("expected a stable export, got:\n%s\nthen:\n%s", exported, again.String())
+	}
+}
+
+func TestExportResourceName(t *testing.T) {
+	cases := []struct {
+		names    []string
+		expected string
+	}{
+		{[]string{"www.example.com"}, "www_example_com"},
+		{[]string{"My Service", "Fastly"}, "my_service_fastly"},
+		{[]string{"2024-site", "--"}, "_2024_site"},
+		{[]string{""}, "_"},
+	}
+	for _, c := range cases {
+		if name := exportResourceName(c.names...); name != c.expected {
+			t.Fatalf("expected %s for %v, got %s", c.expected, c.names, name)
+		}
+	}
+
+	e := newExporter(context.Background(), nil)
+	if a, b := e.uniqueName("ioriver_service", "svc"), e.uniqueName("ioriver_service", "svc"); a != "svc" || b != "svc_2" {
+		t.Fatalf("expected svc and svc_2, got %s and %s", a, b)
+	}
+}
@@ -15,6 +15,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &HealthMonitorResource{}
 var _ resource.ResourceWithImportState = &HealthMonitorResource{}
+var _ ScopedResource = &HealthMonitorResource{}
 
 func NewHealthMonitorResource() resource.Resource {
 	return &HealthMonitorResource{}
@@ -26,7 +27,7 @@ type HealthMonitorResourceId struct {
 }
 
 type HealthMonitorResource struct {
-	client *ioriver.IORiverClient
+	client *Client
 }
 
 type HealthMonitorResourceModel struct {
@@ -147,22 +148,30 @@ func (r *HealthMonitorResource) ImportState(ctx context.Context, req resource.Im
 
 // ------- Implement base Resource API ---------
 
-func (HealthMonitorResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
-	return client.CreateHealthMonitor(newObj.(ioriver.HealthMonitor))
+func (HealthMonitorResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
+	return callCreate(ctx, client, func() (*ioriver.HealthMonitor, error) {
+		return client.CreateHealthMonitor(newObj.(ioriver.HealthMonitor))
+	})
 }
 
-func (HealthMonitorResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
+func (HealthMonitorResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
 	resourceId := id.(HealthMonitorResourceId)
-	return client.GetHealthMonitor(resourceId.serviceId, resourceId.healthMonitorId)
+	return callIdempotent(ctx, client, func() (*ioriver.HealthMonitor, error) {
+		return client.GetHealthMonitor(resourceId.serviceId, resourceId.healthMonitorId)
+	})
 }
 
-func (HealthMonitorResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
-	return client.UpdateHealthMonitor(obj.(ioriver.HealthMonitor))
+func (HealthMonitorResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
+	return callIdempotent(ctx, client, func() (*ioriver.HealthMonitor, error) {
+		return client.UpdateHealthMonitor(obj.(ioriver.HealthMonitor))
+	})
 }
 
-func (HealthMonitorResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
+func (HealthMonitorResource) delete(ctx context.Context, client *Client, id interface{}) error {
 	resourceId := id.(HealthMonitorResourceId)
-	return client.DeleteHealthMonitor(resourceId.serviceId, resourceId.healthMonitorId)
+	return callDelete(ctx, client, func() error {
+		return client.DeleteHealthMonitor(resourceId.serviceId, resourceId.healthMonitorId)
+	})
 }
 
 func (HealthMonitorResource) getId(data interface{}) interface{} {
@@ -172,6 +181,11 @@ func (HealthMonitorResource) getId(data interface{}) interface{} {
 	return HealthMonitorResourceId{healthMonitorId, serviceId}
 }
 
+func (HealthMonitorResource) lockScope(data interface{}) string {
+	d := data.(HealthMonitorResourceModel)
+	return ServiceLockScope(d.Service.ValueString())
+}
+
 // Convert HealthMonitor resource to HealthMonitor API object
 func (HealthMonitorResource) resourceToObj(ctx context.Context, data interface{}) (interface{}, error) {
 	d := data.(HealthMonitorResourceModel)
@@ -27,17 +27,17 @@ type TestedHealthMonitor struct {
 	TestedObj[ioriver.HealthMonitor]
 }
 
-func (TestedHealthMonitor) Get(client *ioriver.IORiverClient, id string) (*ioriver.HealthMonitor, error) {
+func (TestedHealthMonitor) Get(client *Client, id string) (*ioriver.HealthMonitor, error) {
 	serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
 	return client.GetHealthMonitor(serviceId, id)
 }
 
-func (TestedHealthMonitor) List(client *ioriver.IORiverClient) ([]ioriver.HealthMonitor, error) {
+func (TestedHealthMonitor) List(client *Client) ([]ioriver.HealthMonitor, error) {
 	serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
 	return client.ListHealthMonitors(serviceId)
 }
 
-func (TestedHealthMonitor) Delete(client *ioriver.IORiverClient, object ioriver.HealthMonitor, excludeIds []string) error {
+func (TestedHealthMonitor) Delete(client *Client, object ioriver.HealthMonitor, excludeIds []string) error {
 	idx := slices.IndexFunc(excludeIds, func(id string) bool { return id == object.Id })
 	if idx < 0 {
 		serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
@@ -5,21 +5,21 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
-	ioriver "github.com/ioriver/ioriver-go"
 )
 
 func TestMain(m *testing.M) {
+	if os.Getenv(testMockAPIEnvVar) != "" {
+		// nothing to sweep in the mock, which is closed once the tests are done
+		server := startMockAPI()
+		code := m.Run()
+		server.Close()
+		os.Exit(code)
+	}
 	resource.TestMain(m)
 }
 
-func sharedClient() (*ioriver.IORiverClient, error) {
-	client := ioriver.NewClient(os.Getenv("IORIVER_API_TOKEN"))
-
-	apiEndpoint := ""
-	if apiEndpoint != "" {
-		client.EndpointUrl = apiEndpoint
-	}
-	client.TerraformVersion = "test"
+func sharedClient() (*Client, error) {
+	client := NewClient(os.Getenv(APIEndpointEnvVar), os.Getenv(APITokenEvnVar), "test")
 
 	return client, nil
 }
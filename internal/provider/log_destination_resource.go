@@ -12,7 +12,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	ioriver "github.com/ioriver/ioriver-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -230,19 +229,19 @@ func (r *LogDestinationResource) ImportState(ctx context.Context, req resource.I
 
 // ------- Implement base Resource API ---------
 
-func (LogDestinationResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
+func (LogDestinationResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (LogDestinationResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
+func (LogDestinationResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (LogDestinationResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
+func (LogDestinationResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (LogDestinationResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
+func (LogDestinationResource) delete(ctx context.Context, client *Client, id interface{}) error {
 	return nil
 }
 
@@ -14,7 +14,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	ioriver "github.com/ioriver/ioriver-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -216,19 +215,19 @@ func (r *OriginResource) ImportState(ctx context.Context, req resource.ImportSta
 
 // ------- Implement base Resource API ---------
 
-func (OriginResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
+func (OriginResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (OriginResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
+func (OriginResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (OriginResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
+func (OriginResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (OriginResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
+func (OriginResource) delete(ctx context.Context, client *Client, id interface{}) error {
 	return nil
 }
 
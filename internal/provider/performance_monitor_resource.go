@@ -15,6 +15,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &PerformanceMonitorResource{}
 var _ resource.ResourceWithImportState = &PerformanceMonitorResource{}
+var _ ScopedResource = &PerformanceMonitorResource{}
 
 func NewPerformanceMonitorResource() resource.Resource {
 	return &PerformanceMonitorResource{}
@@ -26,7 +27,7 @@ type PerformanceMonitorResourceId struct {
 }
 
 type PerformanceMonitorResource struct {
-	client *ioriver.IORiverClient
+	client *Client
 }
 
 type PerformanceMonitorResourceModel struct {
@@ -147,22 +148,30 @@ func (r *PerformanceMonitorResource) ImportState(ctx context.Context, req resour
 
 // ------- Implement base Resource API ---------
 
-func (PerformanceMonitorResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
-	return client.CreatePerformanceMonitor(newObj.(ioriver.PerformanceMonitor))
+func (PerformanceMonitorResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
+	return callCreate(ctx, client, func() (*ioriver.PerformanceMonitor, error) {
+		return client.CreatePerformanceMonitor(newObj.(ioriver.PerformanceMonitor))
+	})
 }
 
-func (PerformanceMonitorResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
+func (PerformanceMonitorResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
 	resourceId := id.(PerformanceMonitorResourceId)
-	return client.GetPerformanceMonitor(resourceId.serviceId, resourceId.performanceMonitorId)
+	return callIdempotent(ctx, client, func() (*ioriver.PerformanceMonitor, error) {
+		return client.GetPerformanceMonitor(resourceId.serviceId, resourceId.performanceMonitorId)
+	})
 }
 
-func (PerformanceMonitorResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
-	return client.UpdatePerformanceMonitor(obj.(ioriver.PerformanceMonitor))
+func (PerformanceMonitorResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
+	return callIdempotent(ctx, client, func() (*ioriver.PerformanceMonitor, error) {
+		return client.UpdatePerformanceMonitor(obj.(ioriver.PerformanceMonitor))
+	})
 }
 
-func (PerformanceMonitorResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
+func (PerformanceMonitorResource) delete(ctx context.Context, client *Client, id interface{}) error {
 	resourceId := id.(PerformanceMonitorResourceId)
-	return client.DeletePerformanceMonitor(resourceId.serviceId, resourceId.performanceMonitorId)
+	return callDelete(ctx, client, func() error {
+		return client.DeletePerformanceMonitor(resourceId.serviceId, resourceId.performanceMonitorId)
+	})
 }
 
 func (PerformanceMonitorResource) getId(data interface{}) interface{} {
@@ -172,6 +181,11 @@ func (PerformanceMonitorResource) getId(data interface{}) interface{} {
 	return PerformanceMonitorResourceId{performanceMonitorId, serviceId}
 }
 
+func (PerformanceMonitorResource) lockScope(data interface{}) string {
+	d := data.(PerformanceMonitorResourceModel)
+	return ServiceLockScope(d.Service.ValueString())
+}
+
 // Convert PerformanceMonitor resource to PerformanceMonitor API object
 func (PerformanceMonitorResource) resourceToObj(ctx context.Context, data interface{}) (interface{}, error) {
 	d := data.(PerformanceMonitorResourceModel)
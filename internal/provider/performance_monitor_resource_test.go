@@ -27,17 +27,17 @@ type TestedPerformanceMonitor struct {
 	TestedObj[ioriver.PerformanceMonitor]
 }
 
-func (TestedPerformanceMonitor) Get(client *ioriver.IORiverClient, id string) (*ioriver.PerformanceMonitor, error) {
+func (TestedPerformanceMonitor) Get(client *Client, id string) (*ioriver.PerformanceMonitor, error) {
 	serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
 	return client.GetPerformanceMonitor(serviceId, id)
 }
 
-func (TestedPerformanceMonitor) List(client *ioriver.IORiverClient) ([]ioriver.PerformanceMonitor, error) {
+func (TestedPerformanceMonitor) List(client *Client) ([]ioriver.PerformanceMonitor, error) {
 	serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
 	return client.ListPerformanceMonitors(serviceId)
 }
 
-func (TestedPerformanceMonitor) Delete(client *ioriver.IORiverClient, object ioriver.PerformanceMonitor, excludeIds []string) error {
+func (TestedPerformanceMonitor) Delete(client *Client, object ioriver.PerformanceMonitor, excludeIds []string) error {
 	idx := slices.IndexFunc(excludeIds, func(id string) bool { return id == object.Id })
 	if idx < 0 {
 		serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	ioriver "github.com/ioriver/ioriver-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -124,19 +123,19 @@ func (r *ProtocolConfigResource) ImportState(ctx context.Context, req resource.I
 
 // ------- Implement base Resource API ---------
 
-func (ProtocolConfigResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
+func (ProtocolConfigResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (ProtocolConfigResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
+func (ProtocolConfigResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (ProtocolConfigResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
+func (ProtocolConfigResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (ProtocolConfigResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
+func (ProtocolConfigResource) delete(ctx context.Context, client *Client, id interface{}) error {
 	return nil
 }
 
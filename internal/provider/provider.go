@@ -4,14 +4,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	ioriver "github.com/ioriver/ioriver-go"
 )
 
 // Ensure IORiverProvider satisfies various provider interfaces.
@@ -27,8 +29,11 @@ type IORiverProvider struct {
 
 // IORiverProviderModel describes the provider data model.
 type IORiverProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	Token    types.String `tfsdk:"token"`
+	Endpoint     types.String `tfsdk:"endpoint"`
+	Token        types.String `tfsdk:"token"`
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	MaxBackoff   types.Int64  `tfsdk:"max_backoff"`
+	PollInterval types.Int64  `tfsdk:"poll_interval"`
 }
 
 func (p *IORiverProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -48,6 +53,27 @@ func (p *IORiverProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				MarkdownDescription: "IO River API token",
 				Optional:            true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of retries of an API call which failed due to throttling or a transient error. Set to 0 to disable retries. Defaults to %d", DefaultMaxRetries),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_backoff": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum time in seconds to wait between retries of an API call. Defaults to %d", int(DefaultMaxBackoff.Seconds())),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"poll_interval": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Interval in seconds between status checks while waiting for an asynchronous operation, e.g. a service provider activation, to complete. The total wait is limited by the timeouts of the resource. Defaults to %d", int(DefaultPollInterval.Seconds())),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -72,15 +98,23 @@ func (p *IORiverProvider) Configure(ctx context.Context, req provider.ConfigureR
 	}
 
 	if endpoint == "" {
-		endpoint = "https://manage.ioriver.io/api/"
+		endpoint = APIDefaultEndpoint
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("IORiver version: %s", p.version))
 
 	// client configuration for data sources and resources
-	client := ioriver.NewClient(apiToken)
-	client.EndpointUrl = endpoint
-	client.TerraformVersion = p.version
+	client := NewClient(endpoint, apiToken, p.version)
+	if !data.MaxRetries.IsNull() {
+		client.retryConfig.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if !data.MaxBackoff.IsNull() {
+		client.retryConfig.MaxBackoff = time.Duration(data.MaxBackoff.ValueInt64()) * time.Second
+	}
+	if !data.PollInterval.IsNull() {
+		client.pollInterval = time.Duration(data.PollInterval.ValueInt64()) * time.Second
+	}
+
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
@@ -88,6 +122,7 @@ func (p *IORiverProvider) Configure(ctx context.Context, req provider.ConfigureR
 func (p *IORiverProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewCertificateResource,
+		NewCertificateValidationResource,
 		NewAccountProviderResource,
 		NewServiceResource,
 		NewDomainResource,
@@ -106,7 +141,14 @@ func (p *IORiverProvider) Resources(ctx context.Context) []func() resource.Resou
 }
 
 func (p *IORiverProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewServiceDataSource,
+		NewServicesDataSource,
+		NewCertificateDataSource,
+		NewAccountProvidersDataSource,
+		NewServiceProvidersDataSource,
+		NewServiceConfigVersionsDataSource,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -12,30 +12,70 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	ioriver "github.com/ioriver/ioriver-go"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
 )
 
+// When set, acceptance tests run against an in-memory mock of the management
+// API instead of a live account, so no network or credentials are needed.
+const testMockAPIEnvVar = "IORIVER_TEST_MOCK_API"
+
 func generateRandomResourceName() string {
 	return acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 }
 
-func createTestClient() *ioriver.IORiverClient {
-	var client = ioriver.NewClient(os.Getenv(APITokenEvnVar))
-
-	client.TerraformVersion = "test"
-
-	if endpoint := os.Getenv(APIEndpointEnvVar); endpoint != "" {
-		client.EndpointUrl = endpoint
-	}
-
-	return client
+func createTestClient() *Client {
+	return NewClient(os.Getenv(APIEndpointEnvVar), os.Getenv(APITokenEvnVar), "test")
 }
 
-var testAccClient *ioriver.IORiverClient = createTestClient()
+var testAccClient *Client = createTestClient()
 
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"ioriver": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// startMockAPI starts the mock management API, seeds the fixtures referenced
+// by the IORIVER_TEST_* variables and points the test environment at it.
+func startMockAPI() *mockapi.Server {
+	server := mockapi.NewServer()
+
+	certId := server.AddCertificate("mock-certificate", "SELF_MANAGED", "*.mock.ioriver.test")
+	serviceId := server.AddService("mock-service", certId, nil)
+	accountProviderId := server.AddAccountProvider(ioriver.Fastly, "mock-fastly")
+	serviceProviderId := server.AddServiceObject(serviceId, "service-providers", map[string]interface{}{
+		"account_provider": accountProviderId,
+		"name":             "Fastly",
+	})
+	trafficPolicyId := server.AddServiceObject(serviceId, "traffic-policies", map[string]interface{}{
+		"type":       ioriver.TRAFFIC_POLICY_STATIC,
+		"failover":   false,
+		"is_default": true,
+		"providers":  []map[string]interface{}{{"service_provider": serviceProviderId}},
+		"geos":       []map[string]interface{}{{}},
+	})
+
+	env := map[string]string{
+		APIEndpointEnvVar:                        server.Endpoint(),
+		APITokenEvnVar:                           "mock-api-token",
+		"IORIVER_TEST_SERVICE_ID":                serviceId,
+		"IORIVER_TEST_DOMAIN":                    "www.mock.ioriver.test",
+		"IORIVER_TEST_CERT_ID":                   certId,
+		"IORIVER_TEST_DOMAIN_ID":                 GenerateUUID(),
+		"IORIVER_TEST_ORIGIN_ID":                 GenerateUUID(),
+		"IORIVER_TEST_SERVICE_PROVIDER_ID":       serviceProviderId,
+		"IORIVER_TEST_DEFAULT_BEHAVIOR_ID":       GenerateUUID(),
+		"IORIVER_TEST_DEFAULT_TRAFFIC_POLICY_ID": trafficPolicyId,
+		"IORIVER_TEST_FASTLY_API_TOKEN":          "mock-fastly-token",
+	}
+	for key, value := range env {
+		os.Setenv(key, value)
+	}
+
+	// the package-level client was created before the environment was set
+	testAccClient = createTestClient()
+
+	return server
+}
+
 func testAccPreCheck(t *testing.T) {
 	testAccPreEnvVariable(t, "IORIVER_API_TOKEN")
 	testAccPreEnvVariable(t, "IORIVER_TEST_SERVICE_ID")
@@ -58,9 +98,9 @@ func testAccPreCheckV2(t *testing.T) {
 }
 
 type TestedObj[T any] interface {
-	Get(client *ioriver.IORiverClient, id string) (*T, error)
-	List(client *ioriver.IORiverClient) ([]T, error)
-	Delete(client *ioriver.IORiverClient, object T, excludeIds []string) error
+	Get(client *Client, id string) (*T, error)
+	List(client *Client) ([]T, error)
+	Delete(client *Client, object T, excludeIds []string) error
 }
 
 func testAccCheckObjectExists[T interface{}](n string, newObj *T, testedObj TestedObj[T]) resource.TestCheckFunc {
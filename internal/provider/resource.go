@@ -8,26 +8,94 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
 
-	ioriver "github.com/ioriver/ioriver-go"
+// Lock scopes used to serialize modifications. Operations within the same scope
+// run one at a time, operations in different scopes run in parallel.
+const (
+	// GlobalLockScope is used by resources which don't define a narrower scope
+	GlobalLockScope = "global"
+	// CertificatesLockScope covers account-level certificate objects
+	CertificatesLockScope = "account/certificates"
+	// AccountProvidersLockScope covers account-level account-provider objects
+	AccountProvidersLockScope = "account/account-providers"
 )
 
-// protect parallel resource modification using this lock
-var mutex sync.Mutex
+// ServiceLockScope returns the lock scope of a service. The service itself and
+// all objects that belong to it (service providers, traffic policies, monitors)
+// share this scope, since they all modify the same service config version chain.
+func ServiceLockScope(serviceId string) string {
+	return "service/" + serviceId
+}
+
+// operationLocks protects parallel resource modification within a lock scope
+var operationLocks = newScopedMutex()
+
+type scopedMutex struct {
+	mu sync.Mutex
+	// each scope is locked by filling its single-slot channel
+	locks map[string]chan struct{}
+}
+
+func newScopedMutex() *scopedMutex {
+	return &scopedMutex{locks: make(map[string]chan struct{})}
+}
+
+// Lock locks the given scope and returns the matching unlock function. It gives up
+// when the context is done before the lock is acquired.
+func (m *scopedMutex) Lock(ctx context.Context, scope string) (func(), error) {
+	m.mu.Lock()
+	lock, ok := m.locks[scope]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		m.locks[scope] = lock
+	}
+	m.mu.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for lock on %s: %w", scope, ctx.Err())
+	}
+}
 
 type Resource interface {
-	create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error)
-	read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error)
-	update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error)
-	delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error
+	create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error)
+	read(ctx context.Context, client *Client, id interface{}) (interface{}, error)
+	update(ctx context.Context, client *Client, obj interface{}) (interface{}, error)
+	delete(ctx context.Context, client *Client, id interface{}) error
 
 	getId(data interface{}) interface{}
 	resourceToObj(ctx context.Context, data interface{}) (interface{}, error)
 	objToResource(ctx context.Context, obj interface{}, data interface{}) (interface{}, error)
 }
 
-func resourceCreate(client *ioriver.IORiverClient, ctx context.Context, req resource.CreateRequest,
+// AsyncResource is implemented by resources whose objects become ready some time
+// after they are created. The wait runs outside of the operation lock, so other
+// modifications of the same scope can proceed meanwhile.
+type AsyncResource interface {
+	waitReady(ctx context.Context, client *Client, obj interface{}, data interface{}) (interface{}, error)
+}
+
+// ScopedResource is implemented by resources whose modifications only need to be
+// serialized with a subset of other objects. Resources that don't implement it
+// are modified under GlobalLockScope.
+type ScopedResource interface {
+	lockScope(data interface{}) string
+}
+
+func operationLockScope(r Resource, data interface{}) string {
+	if scoped, ok := r.(ScopedResource); ok {
+		return scoped.lockScope(data)
+	}
+	return GlobalLockScope
+}
+
+func resourceCreate(client *Client, ctx context.Context, req resource.CreateRequest,
 	resp *resource.CreateResponse, r Resource, data interface{}, doUpdate bool) interface{} {
 
 	if resp.Diagnostics.HasError() {
@@ -48,10 +116,25 @@ func resourceCreate(client *ioriver.IORiverClient, ctx context.Context, req reso
 	} else {
 		operation = func() (interface{}, error) { return r.update(ctx, client, newObj) }
 	}
-	obj, err := performOperation(func() (interface{}, error) { return operation() })
+	obj, err := performOperation(ctx, operationLockScope(r, data), func() (interface{}, error) { return operation() })
+
+	if async, ok := r.(AsyncResource); ok && err == nil && !doUpdate {
+		obj, err = async.waitReady(ctx, client, obj, data)
+	}
+
+	if err != nil && isWaitError(err) {
+		// the object was created but didn't become ready in time. Keep it in the state,
+		// so terraform marks it as tainted instead of losing track of it.
+		resp.Diagnostics.AddError("Error creating resource", "Resource was created but is not ready: "+err.Error())
+		resourceModel, convErr := r.objToResource(ctx, obj, data)
+		if convErr != nil {
+			return nil
+		}
+		return resourceModel
+	}
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating resource", "Could not create resource, unexpected error: "+err.Error())
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.Plan.Schema, "Error creating resource", "Could not create resource", err)
 		return nil
 	}
 
@@ -62,7 +145,7 @@ func resourceCreate(client *ioriver.IORiverClient, ctx context.Context, req reso
 	return resourceModel
 }
 
-func resourceRead(client *ioriver.IORiverClient, ctx context.Context, req resource.ReadRequest,
+func resourceRead(client *Client, ctx context.Context, req resource.ReadRequest,
 	resp *resource.ReadResponse, r Resource, data interface{}) interface{} {
 
 	if resp.Diagnostics.HasError() {
@@ -74,13 +157,13 @@ func resourceRead(client *ioriver.IORiverClient, ctx context.Context, req resour
 	tflog.Debug(ctx, fmt.Sprintf("Object: %#v", obj))
 
 	if err != nil {
-		if strings.Contains(err.Error(), "404 Not Found") {
+		if isNotFoundError(err) {
 			tflog.Info(ctx, "Object not found")
 			resp.State.RemoveResource(ctx)
 			return nil
 		}
 
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read resource, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.State.Schema, "Client Error", "Unable to read resource", err)
 		return nil
 	}
 
@@ -92,7 +175,7 @@ func resourceRead(client *ioriver.IORiverClient, ctx context.Context, req resour
 	return resourceModel
 }
 
-func resourceUpdate(client *ioriver.IORiverClient, ctx context.Context, req resource.UpdateRequest,
+func resourceUpdate(client *Client, ctx context.Context, req resource.UpdateRequest,
 	resp *resource.UpdateResponse, r Resource, data interface{}) interface{} {
 
 	if resp.Diagnostics.HasError() {
@@ -107,10 +190,10 @@ func resourceUpdate(client *ioriver.IORiverClient, ctx context.Context, req reso
 	tflog.Info(ctx, fmt.Sprintf("Updating IORiver object: %#v", obj))
 
 	updateOp := func() (interface{}, error) { return r.update(ctx, client, obj) }
-	updatedObj, err := performOperation(func() (interface{}, error) { return updateOp() })
+	updatedObj, err := performOperation(ctx, operationLockScope(r, data), func() (interface{}, error) { return updateOp() })
 
 	if err != nil {
-		resp.Diagnostics.AddError("Error updating resource", "Could not update resource, unexpected error: "+err.Error())
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.Plan.Schema, "Error updating resource", "Could not update resource", err)
 		return nil
 	}
 
@@ -121,7 +204,7 @@ func resourceUpdate(client *ioriver.IORiverClient, ctx context.Context, req reso
 	return resourceModel
 }
 
-func resourceDelete(client *ioriver.IORiverClient, ctx context.Context, req resource.DeleteRequest,
+func resourceDelete(client *Client, ctx context.Context, req resource.DeleteRequest,
 	resp *resource.DeleteResponse, r Resource, data interface{}) {
 
 	if resp.Diagnostics.HasError() {
@@ -133,13 +216,32 @@ func resourceDelete(client *ioriver.IORiverClient, ctx context.Context, req reso
 
 	// perform the delete operation
 	deleteOp := func() (interface{}, error) { return nil, r.delete(ctx, client, id) }
-	_, err := performOperation(func() (interface{}, error) { return deleteOp() })
+	_, err := performOperation(ctx, operationLockScope(r, data), func() (interface{}, error) { return deleteOp() })
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete resource, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, req.State.Schema, "Client Error", "Unable to delete resource", err)
 	}
 }
 
+// resolveUnknowns returns the planned value with its unknown values replaced by the
+// values at the same path of the new state. Values missing from the new state are
+// set to null.
+func resolveUnknowns(planned tftypes.Value, state tftypes.Value) (tftypes.Value, error) {
+	return tftypes.Transform(planned, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if v.IsKnown() {
+			return v, nil
+		}
+		stateValue, _, err := tftypes.WalkAttributePath(state, p)
+		if err != nil {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		if known, ok := stateValue.(tftypes.Value); ok && known.Type().Equal(v.Type()) {
+			return known, nil
+		}
+		return tftypes.NewValue(v.Type(), nil), nil
+	})
+}
+
 func serviceResourceImport(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	idParts := strings.Split(req.ID, ",")
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
@@ -154,18 +256,18 @@ func serviceResourceImport(ctx context.Context, req resource.ImportStateRequest,
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
 }
 
-func ConfigureBase(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) *ioriver.IORiverClient {
+func ConfigureBase(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) *Client {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return nil
 	}
 
-	client, ok := req.ProviderData.(*ioriver.IORiverClient)
+	client, ok := req.ProviderData.(*Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *ioriver.IORiverClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return nil
@@ -174,9 +276,33 @@ func ConfigureBase(ctx context.Context, req resource.ConfigureRequest, resp *res
 	return client
 }
 
-// ensures that IO River operations are done sequentially
-func performOperation(operation func() (interface{}, error)) (interface{}, error) {
-	mutex.Lock()
-	defer mutex.Unlock()
+// ensures that IO River operations within the same lock scope are done sequentially
+func performOperation(ctx context.Context, scope string, operation func() (interface{}, error)) (interface{}, error) {
+	unlock, err := operationLocks.Lock(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 	return operation()
 }
+
+// withoutWriteOnly returns a copy of a schema attribute and its nested attributes
+// which are not write-only. It is used for the prior schemas of state upgrades,
+// when the attribute was stored in state.
+func withoutWriteOnly(attribute schema.Attribute) schema.Attribute {
+	switch a := attribute.(type) {
+	case schema.StringAttribute:
+		a.WriteOnly = false
+		return a
+	case schema.SingleNestedAttribute:
+		a.WriteOnly = false
+		attributes := make(map[string]schema.Attribute, len(a.Attributes))
+		for name, nested := range a.Attributes {
+			attributes[name] = withoutWriteOnly(nested)
+		}
+		a.Attributes = attributes
+		return a
+	}
+
+	panic(fmt.Sprintf("unsupported write-only attribute type %T", attribute))
+}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestScopedMutex_SameScopeIsSerialized(t *testing.T) {
+	locks := newScopedMutex()
+
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := locks.Lock(context.Background(), ServiceLockScope("svc"))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer unlock()
+
+			current := atomic.AddInt32(&running, 1)
+			for {
+				prev := atomic.LoadInt32(&maxRunning)
+				if current <= prev || atomic.CompareAndSwapInt32(&maxRunning, prev, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}()
+	}
+	wg.Wait()
+
+	if maxRunning != 1 {
+		t.Fatalf("expected operations in the same scope to run one at a time, got %d concurrently", maxRunning)
+	}
+}
+
+func TestScopedMutex_DifferentScopesRunInParallel(t *testing.T) {
+	locks := newScopedMutex()
+
+	unlockA, _ := locks.Lock(context.Background(), ServiceLockScope("a"))
+	defer unlockA()
+
+	done := make(chan struct{})
+	go func() {
+		unlockB, _ := locks.Lock(context.Background(), ServiceLockScope("b"))
+		unlockB()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected lock on a different scope not to block")
+	}
+}
+
+func TestScopedMutex_LockGivesUpWhenContextIsDone(t *testing.T) {
+	locks := newScopedMutex()
+
+	unlock, _ := locks.Lock(context.Background(), ServiceLockScope("svc"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := locks.Lock(ctx, ServiceLockScope("svc")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected lock to give up with the context error, got %v", err)
+	}
+
+	// the scope is still usable once released
+	unlock()
+	unlock, err := locks.Lock(context.Background(), ServiceLockScope("svc"))
+	if err != nil {
+		t.Fatalf("expected lock to succeed after release, got %v", err)
+	}
+	unlock()
+}
+
+func TestOperationLockScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        Resource
+		data     interface{}
+		expected string
+	}{
+		{
+			name:     "service",
+			r:        ServiceResource{},
+			data:     ServiceResourceModel{Id: types.StringValue("svc-1"), Name: types.StringValue("svc")},
+			expected: ServiceLockScope("svc-1"),
+		},
+		{
+			name:     "new service",
+			r:        ServiceResource{},
+			data:     ServiceResourceModel{Id: types.StringUnknown(), Name: types.StringValue("svc")},
+			expected: ServiceLockScope("new/svc"),
+		},
+		{
+			name:     "traffic policy shares its service scope",
+			r:        TrafficPolicyResource{},
+			data:     TrafficPolicyResourceModel{Service: types.StringValue("svc-1")},
+			expected: ServiceLockScope("svc-1"),
+		},
+		{
+			name:     "service provider shares its service scope",
+			r:        ServiceProviderResource{},
+			data:     ServiceProviderResourceModel{Service: types.StringValue("svc-1")},
+			expected: ServiceLockScope("svc-1"),
+		},
+		{
+			name:     "certificate",
+			r:        CertificateResource{},
+			data:     CertificateResourceModel{},
+			expected: CertificatesLockScope,
+		},
+		{
+			name:     "account provider",
+			r:        AccountProviderResource{},
+			data:     AccountProviderResourceModel{},
+			expected: AccountProvidersLockScope,
+		},
+		{
+			name:     "deprecated resource falls back to the global scope",
+			r:        DomainResource{},
+			data:     DomainResourceModel{},
+			expected: GlobalLockScope,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := operationLockScope(tt.r, tt.data); got != tt.expected {
+				t.Errorf("expected scope %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestResolveUnknowns(t *testing.T) {
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name": tftypes.String,
+		"uuid": tftypes.String,
+		"tags": tftypes.List{ElementType: tftypes.String},
+	}}
+	planned := tftypes.NewValue(objType, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "planned"),
+		"uuid": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"tags": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		}),
+	})
+	state := tftypes.NewValue(objType, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "live"),
+		"uuid": tftypes.NewValue(tftypes.String, "uuid-1"),
+		"tags": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{}),
+	})
+
+	resolved, err := resolveUnknowns(planned, state)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := tftypes.NewValue(objType, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "planned"),
+		"uuid": tftypes.NewValue(tftypes.String, "uuid-1"),
+		"tags": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, nil),
+		}),
+	})
+	if !resolved.Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, resolved)
+	}
+}
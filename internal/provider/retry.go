@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	DefaultMaxRetries = 3
+	DefaultMaxBackoff = 30 * time.Second
+
+	// delay before the first retry, doubled on every further attempt
+	retryBaseDelay = 1 * time.Second
+)
+
+// RetryConfig controls how failed API calls are retried
+type RetryConfig struct {
+	MaxRetries int
+	MaxBackoff time.Duration
+	// BaseDelay overrides retryBaseDelay, used by tests
+	BaseDelay time.Duration
+}
+
+var defaultRetryConfig = RetryConfig{
+	MaxRetries: DefaultMaxRetries,
+	MaxBackoff: DefaultMaxBackoff,
+}
+
+// callIdempotent performs a read, update or other call which is safe to repeat.
+// It is retried on throttling, gateway errors and network errors.
+func callIdempotent[T any](ctx context.Context, client *Client, call func() (T, error)) (T, error) {
+	return callWithRetry(ctx, client, isRetryableIdempotent, call)
+}
+
+// callCreate performs a call which creates a new object on the backend. Such a
+// call is only retried when the backend guarantees nothing was created, i.e. the
+// request was throttled or never reached the server.
+func callCreate[T any](ctx context.Context, client *Client, call func() (T, error)) (T, error) {
+	return callWithRetry(ctx, client, isRetryableCreate, call)
+}
+
+// callDelete performs a delete call with the same policy as callIdempotent. If a
+// retried delete reports the object as not found, the previous attempt already
+// deleted it and the call is considered successful.
+func callDelete(ctx context.Context, client *Client, call func() error) error {
+	attempt := 0
+	_, err := callIdempotent(ctx, client, func() (interface{}, error) {
+		attempt++
+		err := call()
+		if err != nil && attempt > 1 && apiErrorStatusCode(err) == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	})
+	return err
+}
+
+func callWithRetry[T any](ctx context.Context, client *Client, retryable func(error) bool, call func() (T, error)) (T, error) {
+	config := client.retryConfig
+
+	for attempt := 0; ; attempt++ {
+		result, err := call()
+		if err == nil || attempt >= config.MaxRetries || !retryable(err) {
+			return result, wrapAPIError(err)
+		}
+
+		delay, ok := retryDelay(config, attempt, err)
+		if !ok {
+			return result, wrapAPIError(err)
+		}
+
+		tflog.Warn(ctx, fmt.Sprintf("IORiver API call failed, retrying in %s (attempt %d/%d): %s",
+			delay, attempt+1, config.MaxRetries, err))
+
+		select {
+		case <-ctx.Done():
+			return result, wrapAPIError(err)
+		case <-time.After(delay):
+		}
+	}
+}
+
+// retryDelay returns how long to wait before the next attempt. A delay requested
+// by the backend (Retry-After) is honored as is; when it exceeds the configured
+// maximum backoff the call is not retried.
+func retryDelay(config RetryConfig, attempt int, err error) (time.Duration, bool) {
+	if retryAfter, ok := apiErrorRetryAfter(err); ok {
+		if config.MaxBackoff > 0 && retryAfter > config.MaxBackoff {
+			return 0, false
+		}
+		return retryAfter, true
+	}
+
+	base := config.BaseDelay
+	if base <= 0 {
+		base = retryBaseDelay
+	}
+	delay := base << attempt
+	if config.MaxBackoff > 0 && (delay > config.MaxBackoff || delay <= 0) {
+		delay = config.MaxBackoff
+	}
+
+	// equal jitter: wait somewhere between half and the full delay
+	half := delay / 2
+	return half + time.Duration(rand.Int64N(int64(half)+1)), true
+}
+
+func isRetryableIdempotent(err error) bool {
+	switch apiErrorStatusCode(err) {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case 0:
+		var netErr net.Error
+		return errors.As(err, &netErr)
+	}
+	return false
+}
+
+func isRetryableCreate(err error) bool {
+	if apiErrorStatusCode(err) == http.StatusTooManyRequests {
+		return true
+	}
+	// the connection was never established, so the request was not sent
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+var throttledMessagePattern = regexp.MustCompile(`(?i)expected available in ([0-9]+)`)
+
+// apiErrorRetryAfter returns the delay requested by the backend. Raw API calls
+// carry the Retry-After header of the response. The ioriver-go client doesn't
+// expose the response headers, so its errors fall back to the throttling message
+// of the body ("Expected available in 5 seconds").
+func apiErrorRetryAfter(err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.fromResponse {
+		return apiErr.RetryAfter, apiErr.RetryAfter > 0
+	}
+	match := throttledMessagePattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0, false
+	}
+	seconds, convErr := strconv.Atoi(match[1])
+	if convErr != nil {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
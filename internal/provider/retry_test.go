@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(maxRetries int, maxBackoff time.Duration) *Client {
+	client := NewClient("", "", "test")
+	client.retryConfig = RetryConfig{
+		MaxRetries: maxRetries,
+		MaxBackoff: maxBackoff,
+		BaseDelay:  time.Millisecond,
+	}
+	return client
+}
+
+func statusError(status string) error {
+	return fmt.Errorf("status: %s, body: {}", status)
+}
+
+func TestCallIdempotent_RetriesTransientErrors(t *testing.T) {
+	client := newRetryTestClient(3, time.Second)
+
+	for _, status := range []string{"429 Too Many Requests", "502 Bad Gateway", "503 Service Unavailable", "504 Gateway Timeout"} {
+		calls := 0
+		result, err := callIdempotent(context.Background(), client, func() (string, error) {
+			calls++
+			if calls < 3 {
+				return "", statusError(status)
+			}
+			return "ok", nil
+		})
+		if err != nil || result != "ok" {
+			t.Fatalf("%s: expected success after retries, got %q, %v", status, result, err)
+		}
+		if calls != 3 {
+			t.Fatalf("%s: expected 3 calls, got %d", status, calls)
+		}
+	}
+}
+
+func TestCallIdempotent_StopsAfterMaxRetries(t *testing.T) {
+	client := newRetryTestClient(2, time.Second)
+
+	calls := 0
+	_, err := callIdempotent(context.Background(), client, func() (string, error) {
+		calls++
+		return "", statusError("503 Service Unavailable")
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestCallIdempotent_DoesNotRetryClientErrors(t *testing.T) {
+	client := newRetryTestClient(3, time.Second)
+
+	calls := 0
+	_, err := callIdempotent(context.Background(), client, func() (string, error) {
+		calls++
+		return "", statusError("400 Bad Request")
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("expected a single failed call, got %d calls, %v", calls, err)
+	}
+}
+
+func TestCallCreate_RetryPolicy(t *testing.T) {
+	client := newRetryTestClient(3, time.Second)
+
+	cases := []struct {
+		name          string
+		err           error
+		expectedCalls int
+	}{
+		{"throttled", statusError("429 Too Many Requests"), 4},
+		{"bad gateway", statusError("502 Bad Gateway"), 1},
+		{"dial failure", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, 4},
+		{"read failure", &net.OpError{Op: "read", Err: errors.New("connection reset")}, 1},
+	}
+
+	for _, c := range cases {
+		calls := 0
+		_, err := callCreate(context.Background(), client, func() (string, error) {
+			calls++
+			return "", c.err
+		})
+		if err == nil {
+			t.Fatalf("%s: expected an error", c.name)
+		}
+		if calls != c.expectedCalls {
+			t.Fatalf("%s: expected %d calls, got %d", c.name, c.expectedCalls, calls)
+		}
+	}
+}
+
+func TestCallDelete_NotFoundOnRetry(t *testing.T) {
+	client := newRetryTestClient(3, time.Second)
+
+	calls := 0
+	err := callDelete(context.Background(), client, func() error {
+		calls++
+		if calls == 1 {
+			return statusError("504 Gateway Timeout")
+		}
+		return statusError("404 Not Found")
+	})
+	if err != nil {
+		t.Fatalf("expected delete to succeed, got %v", err)
+	}
+
+	// a not found error on the first attempt is still reported
+	err = callDelete(context.Background(), client, func() error {
+		return statusError("404 Not Found")
+	})
+	if apiErrorStatusCode(err) != 404 {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestCallIdempotent_RetryAfter(t *testing.T) {
+	client := newRetryTestClient(3, 2*time.Second)
+
+	calls := 0
+	start := time.Now()
+	_, err := callIdempotent(context.Background(), client, func() (string, error) {
+		calls++
+		if calls == 1 {
+			return "", statusError(`429 Too Many Requests, body: {"detail":"Request was throttled. Expected available in 1 second."}`)
+		}
+		return "ok", nil
+	})
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected Retry-After to be honored, waited only %s", elapsed)
+	}
+
+	// requested delay longer than max_backoff is not waited for
+	calls = 0
+	_, err = callIdempotent(context.Background(), client, func() (string, error) {
+		calls++
+		return "", statusError(`429 Too Many Requests, body: {"detail":"Request was throttled. Expected available in 60 seconds."}`)
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("expected a single failed call, got %d calls, %v", calls, err)
+	}
+}
+
+func TestCallIdempotent_RetryAfterHeader(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			// the body mentions a longer delay, only the header is honored
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"detail":"Request was throttled. Expected available in 60 seconds."}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"abc"}`)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(3, 2*time.Second)
+	client.EndpointUrl = server.URL
+
+	start := time.Now()
+	result, err := callIdempotent(context.Background(), client, func() (map[string]string, error) {
+		var out map[string]string
+		return out, apiGet(context.Background(), client, "services/", &out)
+	})
+	if err != nil || result["id"] != "abc" {
+		t.Fatalf("expected success, got %v, %v", result, err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected Retry-After header to be honored, waited only %s", elapsed)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestCallIdempotent_ContextCanceled(t *testing.T) {
+	client := NewClient("", "", "test")
+	client.retryConfig = RetryConfig{MaxRetries: 3, MaxBackoff: time.Minute, BaseDelay: time.Minute}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	_, err := callIdempotent(ctx, client, func() (string, error) {
+		calls++
+		return "", statusError("503 Service Unavailable")
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("expected a single failed call, got %d calls, %v", calls, err)
+	}
+}
@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ioriver/ioriver-go"
+	"golang.org/x/exp/slices"
+)
+
+// serviceCertificatesFromModel returns the certificates of a service, the default
+// certificate first. Services have either certificate or certificates set.
+func serviceCertificatesFromModel(ctx context.Context, d ServiceResourceModel) ([]string, diag.Diagnostics) {
+	if d.Certificates.IsNull() || d.Certificates.IsUnknown() {
+		return []string{d.Certificate.ValueString()}, nil
+	}
+	certificates := []string{}
+	diags := d.Certificates.ElementsAs(ctx, &certificates, false)
+	return certificates, diags
+}
+
+// serviceCertificatesToModel returns the certificate and certificates attributes
+// of the certificates of a service. The attribute used by the configuration is
+// kept; on import, certificates is used only for services with several
+// certificates.
+func serviceCertificatesToModel(ctx context.Context, certificates []string, d ServiceResourceModel) (types.String, types.List, diag.Diagnostics) {
+	if d.Certificates.IsNull() && (!d.Certificate.IsNull() || len(certificates) <= 1) {
+		if len(certificates) == 0 {
+			return types.StringNull(), types.ListNull(types.StringType), nil
+		}
+		return types.StringValue(certificates[0]), types.ListNull(types.StringType), nil
+	}
+	list, diags := types.ListValueFrom(ctx, types.StringType, certificates)
+	return types.StringNull(), list, diags
+}
+
+// validateDomainCertificates checks that the certificate of each domain is one of
+// the certificates of the service
+func validateDomainCertificates(ctx context.Context, data ServiceResourceModel, diags *diag.Diagnostics) {
+	if data.Config == nil || data.Config.Domains == nil || data.Certificate.IsUnknown() || data.Certificates.IsUnknown() {
+		return
+	}
+	for _, element := range data.Certificates.Elements() {
+		if element.IsUnknown() {
+			return
+		}
+	}
+	certificates, d := serviceCertificatesFromModel(ctx, data)
+	if d.HasError() {
+		return
+	}
+
+	for i, domain := range *data.Config.Domains {
+		if domain.Certificate.IsNull() || domain.Certificate.IsUnknown() {
+			continue
+		}
+		if !slices.Contains(certificates, domain.Certificate.ValueString()) {
+			diags.AddAttributeError(path.Root("config").AtName("domains").AtListIndex(i).AtName("certificate"),
+				"Unknown domain certificate",
+				fmt.Sprintf("The certificate %s of domain %s is not one of the certificates of the service. Add it to certificates.",
+					domain.Certificate.ValueString(), domain.Domain.ValueString()))
+		}
+	}
+}
+
+// certificateHostnames returns the hostnames a certificate is valid for, and
+// whether they are all known. The SANs are only known when the API returns the
+// certificate content, otherwise only its CN is known. The CN of a MANAGED
+// certificate may be a JSON list of all its names.
+func certificateHostnames(cert *ioriver.Certificate) ([]string, bool) {
+	hostnames := []string{}
+	complete := true
+	if err := json.Unmarshal([]byte(cert.Cn), &hostnames); err != nil && cert.Cn != "" {
+		hostnames = []string{cert.Cn}
+		complete = false
+	}
+	if block, _ := pem.Decode([]byte(cert.Certificate)); block != nil && block.Type == "CERTIFICATE" {
+		if parsed, err := x509.ParseCertificate(block.Bytes); err == nil {
+			complete = true
+			if parsed.Subject.CommonName != "" && !slices.Contains(hostnames, parsed.Subject.CommonName) {
+				hostnames = append(hostnames, parsed.Subject.CommonName)
+			}
+			for _, name := range parsed.DNSNames {
+				if !slices.Contains(hostnames, name) {
+					hostnames = append(hostnames, name)
+				}
+			}
+		}
+	}
+	return hostnames, complete && len(hostnames) > 0
+}
+
+// hostnameCovered reports whether a certificate name covers a hostname. A
+// wildcard covers a single label, e.g. *.example.com covers www.example.com but
+// not example.com or a.b.example.com.
+func hostnameCovered(name string, hostname string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+	if name == hostname {
+		return true
+	}
+	suffix, isWildcard := strings.CutPrefix(name, "*.")
+	if !isWildcard {
+		return false
+	}
+	label, rest, found := strings.Cut(hostname, ".")
+	return found && label != "" && rest == suffix
+}
+
+func certificateCovers(hostnames []string, hostname string) bool {
+	for _, name := range hostnames {
+		if hostnameCovered(name, hostname) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkServiceCertificateCoverage checks during plan that every domain and alias
+// of a service is covered by the certificates of the service. Certificates and
+// domains which are not known yet are checked again on apply.
+func checkServiceCertificateCoverage(ctx context.Context, client *Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || client == nil {
+		return
+	}
+
+	var plan ServiceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	var state *ServiceResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &ServiceResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+	}
+	if resp.Diagnostics.HasError() || !serviceCoverageChanged(plan, state) {
+		return
+	}
+	resp.Diagnostics.Append(serviceCertificateCoverage(ctx, client, plan)...)
+}
+
+// serviceCoverageChanged reports whether the certificates or domains of a service
+// change. On create, stateData is nil.
+func serviceCoverageChanged(planData ServiceResourceModel, stateData *ServiceResourceModel) bool {
+	if stateData == nil || stateData.Config == nil || stateData.Config.Domains == nil || planData.Config == nil || planData.Config.Domains == nil {
+		return true
+	}
+	return !planData.Certificate.Equal(stateData.Certificate) || !planData.Certificates.Equal(stateData.Certificates) ||
+		!slices.EqualFunc(*planData.Config.Domains, *stateData.Config.Domains, domainCoverageEqual)
+}
+
+// serviceCertificateCoverage checks that every domain and alias of a service is
+// covered by its certificate, or by one of the certificates of the service when
+// the domain doesn't choose one. Certificates and domains which are not known
+// yet are not checked. A hostname which is not covered by the known names of
+// certificates whose SANs are not known is only warned about.
+func serviceCertificateCoverage(ctx context.Context, client *Client, plan ServiceResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.Config == nil || plan.Config.Domains == nil || plan.Certificate.IsUnknown() || plan.Certificates.IsUnknown() {
+		return diags
+	}
+	certificates, d := serviceCertificatesFromModel(ctx, plan)
+	if d.HasError() {
+		return diags
+	}
+
+	// hostnames per known certificate, and the certificates whose hostnames are
+	// all known
+	hostnames := map[string][]string{}
+	complete := map[string]bool{}
+	for _, id := range certificates {
+		if id == "" {
+			continue
+		}
+		cert, err := callIdempotent(ctx, client, func() (*ioriver.Certificate, error) { return client.GetCertificate(id) })
+		if err != nil {
+			addAPIErrorDiagnostics(ctx, &diags, nil, "Client Error", "Unable to read certificate "+id, err)
+			return diags
+		}
+		if names, all := certificateHostnames(cert); len(names) > 0 {
+			hostnames[id] = names
+			complete[id] = all
+		}
+	}
+
+	for i, domain := range *plan.Config.Domains {
+		candidates := certificates
+		if !domain.Certificate.IsNull() {
+			if domain.Certificate.IsUnknown() {
+				continue
+			}
+			candidates = []string{domain.Certificate.ValueString()}
+		}
+		if domain.Domain.IsUnknown() || domain.Aliases.IsUnknown() || !hostnamesFound(candidates, hostnames) {
+			continue
+		}
+
+		names := []string{domain.Domain.ValueString()}
+		aliases := []string{}
+		diags.Append(domain.Aliases.ElementsAs(ctx, &aliases, false)...)
+		names = append(names, aliases...)
+
+		for _, name := range names {
+			covered := false
+			for _, id := range candidates {
+				if certificateCovers(hostnames[id], name) {
+					covered = true
+					break
+				}
+			}
+			if covered {
+				continue
+			}
+
+			domainPath := path.Root("config").AtName("domains").AtListIndex(i)
+			detail := fmt.Sprintf("%s of domain %s is not covered by", name, domain.Domain.ValueString())
+			for _, id := range candidates {
+				validFor := strings.Join(hostnames[id], ", ")
+				if !complete[id] {
+					validFor += " and unknown alternative names"
+				}
+				detail += fmt.Sprintf("\n  - certificate %s, valid for %s", id, validFor)
+			}
+			if !allHostnamesKnown(candidates, complete) {
+				diags.AddAttributeWarning(domainPath, "Domain may not be covered by a certificate",
+					detail+"\n\nThe alternative names of the certificates are not returned by the API, so the domain could not be checked. "+
+						"Make sure one of them covers it.")
+				continue
+			}
+			diags.AddAttributeError(domainPath, "Domain not covered by a certificate",
+				detail+"\n\nAdd a certificate covering it to certificates, or choose the certificate of the domain with certificate.")
+		}
+	}
+	return diags
+}
+
+// hostnamesFound reports whether some hostnames of all the certificates were
+// found. Certificates being issued may not have a CN yet.
+func hostnamesFound(certificates []string, hostnames map[string][]string) bool {
+	for _, id := range certificates {
+		if _, ok := hostnames[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// allHostnamesKnown reports whether all the hostnames of the certificates are
+// known, including their alternative names
+func allHostnamesKnown(certificates []string, complete map[string]bool) bool {
+	for _, id := range certificates {
+		if !complete[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func domainCoverageEqual(a DomainModel, b DomainModel) bool {
+	return a.Domain.Equal(b.Domain) && a.Aliases.Equal(b.Aliases) && a.Certificate.Equal(b.Certificate)
+}
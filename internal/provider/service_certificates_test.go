@@ -0,0 +1,322 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	ioriver "github.com/ioriver/ioriver-go"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
+)
+
+func TestHostnameCovered(t *testing.T) {
+	cases := []struct {
+		name     string
+		hostname string
+		expected bool
+	}{
+		{"www.example.com", "www.example.com", true},
+		{"WWW.example.com", "www.example.com.", true},
+		{"www.example.com", "api.example.com", false},
+		{"*.example.com", "api.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "a.b.example.com", false},
+		{"*.example.com", ".example.com", false},
+	}
+	for _, c := range cases {
+		if covered := hostnameCovered(c.name, c.hostname); covered != c.expected {
+			t.Errorf("expected %s covering %s: %t, got %t", c.name, c.hostname, c.expected, covered)
+		}
+	}
+}
+
+func TestCertificateHostnames(t *testing.T) {
+	certificate, _ := generateTestCertificate(t)
+	hostnames, complete := certificateHostnames(&ioriver.Certificate{Cn: "www.example.com", Certificate: certificate})
+	if len(hostnames) != 1 || hostnames[0] != "www.example.com" || !complete {
+		t.Fatalf("unexpected hostnames %v, complete %t", hostnames, complete)
+	}
+
+	// names of a MANAGED certificate
+	hostnames, complete = certificateHostnames(&ioriver.Certificate{Cn: `["example.com","*.example.com"]`})
+	if strings.Join(hostnames, ",") != "example.com,*.example.com" || !complete {
+		t.Fatalf("unexpected hostnames %v, complete %t", hostnames, complete)
+	}
+
+	// the SANs are unknown without the certificate content
+	hostnames, complete = certificateHostnames(&ioriver.Certificate{Cn: "www.example.com"})
+	if len(hostnames) != 1 || complete {
+		t.Fatalf("unexpected hostnames %v, complete %t", hostnames, complete)
+	}
+}
+
+func TestServiceCertificatesToModel(t *testing.T) {
+	ctx := context.Background()
+	configured := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("cert-1")})
+
+	cases := []struct {
+		name         string
+		certificates []string
+		data         ServiceResourceModel
+		certificate  string
+		list         bool
+	}{
+		{"certificate", []string{"cert-1", "cert-2"}, ServiceResourceModel{Certificate: types.StringValue("cert-1"), Certificates: types.ListNull(types.StringType)}, "cert-1", false},
+		{"certificates", []string{"cert-1"}, ServiceResourceModel{Certificate: types.StringNull(), Certificates: configured}, "", true},
+		{"import single", []string{"cert-1"}, ServiceResourceModel{Certificate: types.StringNull(), Certificates: types.ListNull(types.StringType)}, "cert-1", false},
+		{"import several", []string{"cert-1", "cert-2"}, ServiceResourceModel{Certificate: types.StringNull(), Certificates: types.ListNull(types.StringType)}, "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			certificate, certificates, diags := serviceCertificatesToModel(ctx, c.certificates, c.data)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if certificate.ValueString() != c.certificate || certificates.IsNull() == c.list {
+				t.Fatalf("unexpected certificate %s and certificates %s", certificate, certificates)
+			}
+			if c.list && len(certificates.Elements()) != len(c.certificates) {
+				t.Fatalf("expected %v, got %s", c.certificates, certificates)
+			}
+		})
+	}
+}
+
+func TestCreateServiceWithConfig_Certificates(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	service, err := CreateServiceWithConfig(ctx, client, ServiceWithConfig{
+		Name:         "svc",
+		Certificates: []string{"cert-1", "cert-2"},
+		Config:       map[string]interface{}{"name": "svc"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create service: %s", err)
+	}
+	if strings.Join(service.Certificates, ",") != "cert-1,cert-2" {
+		t.Fatalf("expected both certificates, got %v", service.Certificates)
+	}
+}
+
+func TestUpdateServiceWithConfig_Certificates(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	serviceId := server.AddService("svc", "cert-1", map[string]interface{}{"name": "svc"})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	for _, certificates := range [][]string{{"cert-2"}, {"cert-2", "cert-3"}, {"cert-3"}} {
+		service, err := UpdateServiceWithConfig(ctx, client, ServiceWithConfig{
+			Id:           serviceId,
+			Name:         "svc",
+			Certificates: certificates,
+			Config:       map[string]interface{}{"name": "svc"},
+		})
+		if err != nil {
+			t.Fatalf("failed to update service: %s", err)
+		}
+		if strings.Join(service.Certificates, ",") != strings.Join(certificates, ",") {
+			t.Fatalf("expected certificates %v, got %v", certificates, service.Certificates)
+		}
+	}
+}
+
+func TestUpdateServiceWithConfig_CertificatesUnsupported(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	server.DisableServiceCertificates()
+	serviceId := server.AddService("svc", "cert-1", map[string]interface{}{"name": "svc"})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	// a single certificate is still set with UpdateService
+	service, err := UpdateServiceWithConfig(ctx, client, ServiceWithConfig{
+		Id:           serviceId,
+		Name:         "svc",
+		Certificates: []string{"cert-2"},
+		Config:       map[string]interface{}{"name": "svc"},
+	})
+	if err != nil {
+		t.Fatalf("failed to update service: %s", err)
+	}
+	if strings.Join(service.Certificates, ",") != "cert-2" || !service.defaultCertificateOnly {
+		t.Fatalf("expected only the default certificate to be read, got %v", service.Certificates)
+	}
+
+	_, err = UpdateServiceWithConfig(ctx, client, ServiceWithConfig{
+		Id:           serviceId,
+		Name:         "svc",
+		Certificates: []string{"cert-2", "cert-3"},
+		Config:       map[string]interface{}{"name": "svc"},
+	})
+	if !errors.Is(err, errServiceCertificatesUnsupported) {
+		t.Fatalf("expected several certificates to be rejected, got %v", err)
+	}
+}
+
+func TestUpdateServiceWithConfig_CertificatesConflict(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	serviceId := server.AddService("svc", "cert-1", map[string]interface{}{"name": "svc"})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	planned, err := GetServiceWithConfig(ctx, client, serviceId)
+	if err != nil {
+		t.Fatalf("failed to read service: %s", err)
+	}
+
+	// the config is changed outside Terraform after it was read
+	outside := *planned
+	outside.Config = map[string]interface{}{"name": "svc", "waf": "on"}
+	if _, err := UpdateServiceWithConfig(ctx, client, outside); err != nil {
+		t.Fatalf("failed to update service: %s", err)
+	}
+
+	planned.Certificates = []string{"cert-2", "cert-3"}
+	_, err = UpdateServiceWithConfig(ctx, client, *planned)
+	var conflictErr *ConfigConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("expected a config conflict, got %v", err)
+	}
+
+	// the certificates are left as they were
+	current, err := GetServiceWithConfig(ctx, client, serviceId)
+	if err != nil {
+		t.Fatalf("failed to read service: %s", err)
+	}
+	if strings.Join(current.Certificates, ",") != "cert-1" {
+		t.Fatalf("expected the certificates to be unchanged, got %v", current.Certificates)
+	}
+}
+
+func TestServiceCoverageChanged(t *testing.T) {
+	domains := []DomainModel{{Domain: types.StringValue("www.example.com"), Aliases: types.ListNull(types.StringType), Certificate: types.StringNull()}}
+	model := func(certificate string) ServiceResourceModel {
+		return ServiceResourceModel{
+			Certificate:  types.StringValue(certificate),
+			Certificates: types.ListNull(types.StringType),
+			Config:       &ServiceConfigModel{Domains: &domains},
+		}
+	}
+
+	state := model("cert-1")
+	if serviceCoverageChanged(model("cert-1"), &state) {
+		t.Fatal("expected no change for the same certificate and domains")
+	}
+	if !serviceCoverageChanged(model("cert-2"), &state) {
+		t.Fatal("expected a change for a rotated certificate")
+	}
+	if !serviceCoverageChanged(model("cert-1"), nil) {
+		t.Fatal("expected a change on create")
+	}
+}
+
+func TestCheckServiceCertificateCoverage(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	// the content of the certificate isn't returned, so its SANs are unknown
+	wwwCert := server.AddCertificate("www", "SELF_MANAGED", "www.example.com")
+	wildcardCert := server.AddCertificate("wildcard", "MANAGED", `["*.example.org"]`)
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	schemaResp := resource.SchemaResponse{}
+	(&ServiceResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	serviceSchema := schemaResp.Schema
+
+	plan := func(domainCertificate string, aliases ...string) tfsdk.Plan {
+		plan := tfsdk.Plan{Schema: serviceSchema, Raw: tftypes.NewValue(serviceSchema.Type().TerraformType(ctx), nil)}
+		certificate := types.StringNull()
+		if domainCertificate != "" {
+			certificate = types.StringValue(domainCertificate)
+		}
+		aliasesList, diags := types.ListValueFrom(ctx, types.StringType, aliases)
+		domains := []DomainModel{
+			{Domain: types.StringValue("www.example.com"), Aliases: types.ListValueMust(types.StringType, []attr.Value{}), Certificate: types.StringNull()},
+			{Domain: types.StringValue("api.example.org"), Aliases: aliasesList, Certificate: certificate},
+		}
+		diags.Append(plan.SetAttribute(ctx, path.Root("certificates"), []string{wwwCert, wildcardCert})...)
+		diags.Append(plan.SetAttribute(ctx, path.Root("config").AtName("domains"), domains)...)
+		if diags.HasError() {
+			t.Fatalf("failed to build plan: %v", diags)
+		}
+		return plan
+	}
+	check := func(plan tfsdk.Plan) resource.ModifyPlanResponse {
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		state := tfsdk.State{Schema: serviceSchema, Raw: tftypes.NewValue(serviceSchema.Type().TerraformType(ctx), nil)}
+		checkServiceCertificateCoverage(ctx, client, resource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+		return resp
+	}
+
+	// covered by any of the certificates
+	if resp := check(plan("", "static.example.org")); resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	// an alias not covered by the wildcard, whose names are all known
+	resp := check(plan(wildcardCert, "a.b.example.org"))
+	if resp.Diagnostics.ErrorsCount() != 1 || !strings.Contains(resp.Diagnostics[0].Detail(), "a.b.example.org of domain api.example.org") {
+		t.Fatalf("expected an error for the alias, got %v", resp.Diagnostics)
+	}
+
+	// a domain which may be covered by a SAN of the chosen certificate
+	resp = check(plan(wwwCert))
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning for the domain, got %v", resp.Diagnostics)
+	}
+	if !strings.Contains(resp.Diagnostics[0].Detail(), "valid for www.example.com and unknown alternative names") {
+		t.Fatalf("unexpected warning %s", resp.Diagnostics[0].Detail())
+	}
+
+	// an alias which may be covered by a SAN of the www certificate
+	resp = check(plan("", "a.b.example.org"))
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning for the alias, got %v", resp.Diagnostics)
+	}
+}
+
+func TestValidateDomainCertificates(t *testing.T) {
+	ctx := context.Background()
+	domains := []DomainModel{{Domain: types.StringValue("www.example.com"), Certificate: types.StringValue("cert-2")}}
+	data := ServiceResourceModel{
+		Certificate:  types.StringValue("cert-1"),
+		Certificates: types.ListNull(types.StringType),
+		Config:       &ServiceConfigModel{Domains: &domains},
+	}
+
+	var resp resource.ValidateConfigResponse
+	validateDomainCertificates(ctx, data, &resp.Diagnostics)
+	if len(resp.Diagnostics) != 1 {
+		t.Fatalf("expected an error for the unknown certificate, got %v", resp.Diagnostics)
+	}
+
+	data.Certificate = types.StringNull()
+	data.Certificates = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("cert-1"), types.StringValue("cert-2")})
+	resp = resource.ValidateConfigResponse{}
+	validateDomainCertificates(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/exp/slices"
+)
+
+// ServiceConfigVersion is a single version of a service config. The backend keeps
+// every config version posted by UpdateServiceWithConfig.
+type ServiceConfigVersion struct {
+	Id            string                 `json:"id"`
+	Version       int                    `json:"version"`
+	ParentVersion int                    `json:"parent_version"`
+	Description   string                 `json:"description"`
+	CreatedBy     string                 `json:"created_by"`
+	Created       string                 `json:"created"`
+	ConfigJSON    map[string]interface{} `json:"config_json"`
+}
+
+// ListServiceConfigVersions returns all config versions of a service, newest first
+func ListServiceConfigVersions(ctx context.Context, client *Client, serviceId string) ([]ServiceConfigVersion, error) {
+	versions, err := callIdempotent(ctx, client, func() ([]ServiceConfigVersion, error) {
+		var versions []ServiceConfigVersion
+		err := apiGet(ctx, client, "services/"+serviceId+"/service-configs/", &versions)
+		return versions, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Version > versions[j].Version
+	})
+	return versions, nil
+}
+
+// ConfigConflictError is returned when the config of a service was changed
+// outside Terraform after it was last read
+type ConfigConflictError struct {
+	ServiceId       string
+	ExpectedVersion int
+	CurrentVersion  int
+	// Changes holds the versions created after ExpectedVersion, oldest first
+	Changes []ConfigChange
+}
+
+// ConfigChange is a config version together with the config sections it changed
+type ConfigChange struct {
+	Version   int
+	CreatedBy string
+	Created   string
+	// Description of the version, if any
+	Description string
+	Sections    []string
+}
+
+func (e *ConfigConflictError) Error() string {
+	msg := fmt.Sprintf("the config of service %s was changed outside Terraform: expected version %d, found version %d",
+		e.ServiceId, e.ExpectedVersion, e.CurrentVersion)
+	for _, change := range e.Changes {
+		msg += "\n  - " + change.String()
+	}
+	return msg
+}
+
+func (c ConfigChange) String() string {
+	msg := fmt.Sprintf("version %d by %s at %s", c.Version, valueOr(c.CreatedBy, "unknown"), valueOr(c.Created, "unknown time"))
+	if c.Description != "" {
+		msg += fmt.Sprintf(" (%q)", c.Description)
+	}
+	if len(c.Sections) > 0 {
+		msg += ": changed " + strings.Join(c.Sections, ", ")
+	}
+	return msg
+}
+
+// newConfigConflictError describes the config versions created after the expected
+// version. The conflict is reported even when the versions can't be listed.
+func newConfigConflictError(ctx context.Context, client *Client, serviceId string, expected int, current int) *ConfigConflictError {
+	conflict := &ConfigConflictError{ServiceId: serviceId, ExpectedVersion: expected, CurrentVersion: current}
+
+	changes, err := listConfigChanges(ctx, client, serviceId, expected)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Failed to list the config versions of service %s: %s", serviceId, err))
+		return conflict
+	}
+	conflict.Changes = changes
+	return conflict
+}
+
+// listConfigChanges returns the config versions created after the given version, oldest first
+func listConfigChanges(ctx context.Context, client *Client, serviceId string, after int) ([]ConfigChange, error) {
+	versions, err := ListServiceConfigVersions(ctx, client, serviceId)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make(map[int]map[string]interface{}, len(versions))
+	for _, version := range versions {
+		configs[version.Version] = version.ConfigJSON
+	}
+
+	changes := []ConfigChange{}
+	// versions are listed newest first
+	for i := len(versions) - 1; i >= 0; i-- {
+		version := versions[i]
+		if version.Version <= after {
+			continue
+		}
+		changes = append(changes, ConfigChange{
+			Version:     version.Version,
+			CreatedBy:   version.CreatedBy,
+			Created:     version.Created,
+			Description: version.Description,
+			Sections:    changedConfigSections(configs[version.ParentVersion], version.ConfigJSON),
+		})
+	}
+	return changes, nil
+}
+
+// changedConfigSections returns the attributes of the top level config keys which
+// differ between two configs, e.g. config.behaviors
+func changedConfigSections(before map[string]interface{}, after map[string]interface{}) []string {
+	changed := map[string]bool{}
+	for key, value := range after {
+		if !reflect.DeepEqual(before[key], value) {
+			changed[key] = true
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			changed[key] = true
+		}
+	}
+
+	sections := []string{}
+	for key := range changed {
+		if name, ok := configKeyAttributes[key]; ok {
+			key = name
+		}
+		if section := "config." + key; !slices.Contains(sections, section) {
+			sections = append(sections, section)
+		}
+	}
+	sort.Strings(sections)
+	return sections
+}
+
+func valueOr(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// GetServiceConfigVersion returns a single config version of a service
+func GetServiceConfigVersion(ctx context.Context, client *Client, serviceId string, version int) (*ServiceConfigVersion, error) {
+	return callIdempotent(ctx, client, func() (*ServiceConfigVersion, error) {
+		var configVersion ServiceConfigVersion
+		if err := apiGet(ctx, client, fmt.Sprintf("services/%s/service-configs/%d/", serviceId, version), &configVersion); err != nil {
+			return nil, err
+		}
+		return &configVersion, nil
+	})
+}
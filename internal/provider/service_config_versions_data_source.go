@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServiceConfigVersionsDataSource{}
+
+func NewServiceConfigVersionsDataSource() datasource.DataSource {
+	return &ServiceConfigVersionsDataSource{}
+}
+
+type ServiceConfigVersionsDataSource struct {
+	client *Client
+}
+
+type ServiceConfigVersionsDataSourceModel struct {
+	Service        types.String                    `tfsdk:"service"`
+	Limit          types.Int64                     `tfsdk:"limit"`
+	IncludeConfig  types.Bool                      `tfsdk:"include_config"`
+	CurrentVersion types.Int64                     `tfsdk:"current_version"`
+	Versions       []ServiceConfigVersionDataModel `tfsdk:"versions"`
+}
+
+type ServiceConfigVersionDataModel struct {
+	Id            types.String        `tfsdk:"id"`
+	Version       types.Int64         `tfsdk:"version"`
+	ParentVersion types.Int64         `tfsdk:"parent_version"`
+	Description   types.String        `tfsdk:"description"`
+	Author        types.String        `tfsdk:"author"`
+	Created       types.String        `tfsdk:"created"`
+	Config        *ServiceConfigModel `tfsdk:"config"`
+}
+
+func (d *ServiceConfigVersionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_config_versions"
+}
+
+func (d *ServiceConfigVersionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Service config versions data source. Lists the config versions of a service, newest first. Every apply of a service config creates a new version.",
+
+		Attributes: map[string]schema.Attribute{
+			"service": schema.StringAttribute{
+				MarkdownDescription: "The id of the service",
+				Required:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Only return this number of the most recent versions",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"include_config": schema.BoolAttribute{
+				MarkdownDescription: "Decode the config of each version. Defaults to false",
+				Optional:            true,
+			},
+			"current_version": schema.Int64Attribute{
+				MarkdownDescription: "The version number of the live config",
+				Computed:            true,
+			},
+			"versions": schema.ListNestedAttribute{
+				MarkdownDescription: "The config versions, newest first",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Config version identifier",
+							Computed:            true,
+						},
+						"version": schema.Int64Attribute{
+							MarkdownDescription: "Config version number",
+							Computed:            true,
+						},
+						"parent_version": schema.Int64Attribute{
+							MarkdownDescription: "The version this version was based on, 0 for the first version",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Description of the change",
+							Computed:            true,
+						},
+						"author": schema.StringAttribute{
+							MarkdownDescription: "The user or API token which created the version",
+							Computed:            true,
+						},
+						"created": schema.StringAttribute{
+							MarkdownDescription: "Creation time of the version (RFC3339)",
+							Computed:            true,
+						},
+						"config": schema.SingleNestedAttribute{
+							MarkdownDescription: "The config of the version, set only when `include_config` is true",
+							Computed:            true,
+							Attributes:          computedAttributes(ConfigAttributes()),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure data source and retrieve API client
+func (d *ServiceConfigVersionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	client := ConfigureDataSourceBase(ctx, req, resp)
+	if client == nil {
+		return
+	}
+	d.client = client
+}
+
+// Read ServiceConfigVersions data source
+func (d *ServiceConfigVersionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServiceConfigVersionsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	versions, err := ListServiceConfigVersions(ctx, d.client, data.Service.ValueString())
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, nil, "Client Error", "Unable to list service config versions", err)
+		return
+	}
+
+	data.CurrentVersion = types.Int64Value(0)
+	if len(versions) > 0 {
+		data.CurrentVersion = types.Int64Value(int64(versions[0].Version))
+	}
+	if !data.Limit.IsNull() && int64(len(versions)) > data.Limit.ValueInt64() {
+		versions = versions[:data.Limit.ValueInt64()]
+	}
+
+	data.Versions = make([]ServiceConfigVersionDataModel, 0, len(versions))
+	for _, version := range versions {
+		model, err := configVersionToDataSourceModel(ctx, version, data.IncludeConfig.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", "Failed to convert IORiver object to data source: "+err.Error())
+			return
+		}
+		data.Versions = append(data.Versions, model)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func configVersionToDataSourceModel(ctx context.Context, version ServiceConfigVersion, includeConfig bool) (ServiceConfigVersionDataModel, error) {
+	model := ServiceConfigVersionDataModel{
+		Id:            types.StringValue(version.Id),
+		Version:       types.Int64Value(int64(version.Version)),
+		ParentVersion: types.Int64Value(int64(version.ParentVersion)),
+		Description:   types.StringValue(version.Description),
+		Author:        types.StringValue(version.CreatedBy),
+		Created:       types.StringValue(version.Created),
+	}
+	if includeConfig {
+		config, err := configToDataSourceModel(ctx, version.ConfigJSON)
+		if err != nil {
+			return ServiceConfigVersionDataModel{}, err
+		}
+		model.Config = config
+	}
+	return model, nil
+}
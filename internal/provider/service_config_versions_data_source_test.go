@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
+)
+
+var serviceConfigVersionsDataSourceType string = "data.ioriver_service_config_versions"
+
+func TestAccIORiverServiceConfigVersionsDataSource_Basic(t *testing.T) {
+	certId := os.Getenv("IORIVER_TEST_CERT_ID")
+	rndName := generateRandomResourceName()
+	resourceName := serviceResourceType + "." + rndName
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckV2(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServiceConfigVersionsDataSourceConfig(rndName, certId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(serviceConfigVersionsDataSourceType+".test", "versions.#", "1"),
+					resource.TestCheckResourceAttr(serviceConfigVersionsDataSourceType+".test", "current_version", "1"),
+					resource.TestCheckResourceAttrSet(serviceConfigVersionsDataSourceType+".test", "versions.0.created"),
+					resource.TestCheckResourceAttrPair(serviceConfigVersionsDataSourceType+".test", "versions.0.config.uuid", resourceName, "config.uuid"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckServiceConfigVersionsDataSourceConfig(resourceName string, certId string) string {
+	return testAccCheckServiceConfigBasic(resourceName, certId) + fmt.Sprintf(`
+
+data "ioriver_service_config_versions" "test" {
+	service        = %s.%s.id
+	include_config = true
+}`, serviceResourceType, resourceName)
+}
+
+func TestListServiceConfigVersions(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	serviceId := server.AddService("svc", "cert-id", map[string]interface{}{
+		"domains": []interface{}{},
+		"origins": []interface{}{},
+	})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	service, err := GetServiceWithConfig(ctx, client, serviceId)
+	if err != nil {
+		t.Fatalf("failed to read service: %s", err)
+	}
+	service.Description = "second version"
+	if _, err := UpdateServiceWithConfig(ctx, client, *service); err != nil {
+		t.Fatalf("failed to update service: %s", err)
+	}
+
+	versions, err := ListServiceConfigVersions(ctx, client, serviceId)
+	if err != nil {
+		t.Fatalf("failed to list config versions: %s", err)
+	}
+	if len(versions) != 2 || versions[0].Version != 2 || versions[0].ParentVersion != 1 || versions[1].Version != 1 {
+		t.Fatalf("expected versions 2 and 1, newest first, got %+v", versions)
+	}
+	if versions[0].Description != "second version" || versions[0].CreatedBy != mockapi.MockUser {
+		t.Fatalf("unexpected version details: %+v", versions[0])
+	}
+
+	// the decoded versions must fit the data source schema
+	data := ServiceConfigVersionsDataSourceModel{
+		Service:        types.StringValue(serviceId),
+		CurrentVersion: types.Int64Value(int64(versions[0].Version)),
+	}
+	for _, version := range versions {
+		model, err := configVersionToDataSourceModel(ctx, version, true)
+		if err != nil {
+			t.Fatalf("failed to convert version: %s", err)
+		}
+		data.Versions = append(data.Versions, model)
+	}
+	schemaResp := datasource.SchemaResponse{}
+	(&ServiceConfigVersionsDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("failed to set data source state: %v", diags)
+	}
+
+	if _, err := ListServiceConfigVersions(ctx, client, "missing"); !isNotFoundError(err) {
+		t.Fatalf("expected a not found error for a missing service, got %v", err)
+	}
+}
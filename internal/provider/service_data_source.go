@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServiceDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &ServiceDataSource{}
+
+func NewServiceDataSource() datasource.DataSource {
+	return &ServiceDataSource{}
+}
+
+type ServiceDataSource struct {
+	client *Client
+}
+
+type ServiceDataSourceModel struct {
+	Id           types.String        `tfsdk:"id"`
+	Name         types.String        `tfsdk:"name"`
+	Description  types.String        `tfsdk:"description"`
+	Cname        types.String        `tfsdk:"cname"`
+	Certificate  types.String        `tfsdk:"certificate"`
+	Certificates []types.String      `tfsdk:"certificates"`
+	ServiceUid   types.String        `tfsdk:"service_uid"`
+	Config       *ServiceConfigModel `tfsdk:"config"`
+}
+
+func (d *ServiceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service"
+}
+
+func (d *ServiceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Service data source. Looks up an existing service by id or by name.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Service identifier",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Service name",
+				Optional:            true,
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Service description",
+				Computed:            true,
+			},
+			"cname": schema.StringAttribute{
+				MarkdownDescription: "CNAME for the IO River service",
+				Computed:            true,
+			},
+			"certificate": schema.StringAttribute{
+				MarkdownDescription: "ID of the default certificate of the service",
+				Computed:            true,
+			},
+			"certificates": schema.ListAttribute{
+				MarkdownDescription: "IDs of all the certificates of the service, the default certificate first",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"service_uid": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier for the service",
+				Computed:            true,
+			},
+			"config": schema.SingleNestedAttribute{
+				MarkdownDescription: "Service configuration",
+				Computed:            true,
+				Attributes:          computedAttributes(ConfigAttributes()),
+			},
+		},
+	}
+}
+
+func (d *ServiceDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+// Configure data source and retrieve API client
+func (d *ServiceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	client := ConfigureDataSourceBase(ctx, req, resp)
+	if client == nil {
+		return
+	}
+	d.client = client
+}
+
+// Read Service data source
+func (d *ServiceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServiceDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.Id.ValueString()
+	if data.Id.IsNull() {
+		var err error
+		id, err = findServiceIdByName(ctx, d.client, data.Name.ValueString())
+		if err != nil {
+			addAPIErrorDiagnostics(ctx, &resp.Diagnostics, nil, "Client Error", "Unable to find service", err)
+			return
+		}
+	}
+
+	service, err := GetServiceWithConfig(ctx, d.client, id)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, nil, "Client Error", "Unable to read service", err)
+		return
+	}
+
+	newData, err := serviceToDataSourceModel(ctx, service)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", "Failed to convert IORiver object to data source: "+err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newData)...)
+}
+
+func findServiceIdByName(ctx context.Context, client *Client, name string) (string, error) {
+	services, err := callIdempotent(ctx, client, client.ListServices)
+	if err != nil {
+		return "", err
+	}
+
+	ids := []string{}
+	for _, service := range services {
+		if service.Name == name {
+			ids = append(ids, service.Id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no service named %q was found", name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("found %d services named %q, use the service id instead: %v", len(ids), name, ids)
+	}
+}
+
+func serviceToDataSourceModel(ctx context.Context, service *ServiceWithConfig) (ServiceDataSourceModel, error) {
+	configModel, err := configToDataSourceModel(ctx, service.Config)
+	if err != nil {
+		return ServiceDataSourceModel{}, err
+	}
+
+	certificate := ""
+	var certificates []types.String
+	if !service.defaultCertificateOnly {
+		certificates = []types.String{}
+		for _, id := range service.Certificates {
+			certificates = append(certificates, types.StringValue(id))
+		}
+	}
+	if len(service.Certificates) > 0 {
+		certificate = service.Certificates[0]
+	}
+
+	return ServiceDataSourceModel{
+		Id:           types.StringValue(service.Id),
+		Name:         types.StringValue(service.Name),
+		Description:  types.StringValue(service.Description),
+		Cname:        types.StringValue(service.Cname),
+		Certificate:  types.StringValue(certificate),
+		Certificates: certificates,
+		ServiceUid:   types.StringValue(service.ServiceUid),
+		Config:       configModel,
+	}, nil
+}
+
+// configToDataSourceModel decodes a service config for data sources. There is no
+// HCL to follow, so the config is decoded the same way as on import.
+func configToDataSourceModel(ctx context.Context, config map[string]interface{}) (*ServiceConfigModel, error) {
+	transformCtx := &ServiceTransformContext{
+		OriginNamesToUUIDs:  make(map[string]string),
+		DesiredOriginOrder:  []string{},
+		LogDestNamesToUUIDs: make(map[string]string),
+		DesiredLogDestOrder: []string{},
+		SecurityConfigured:  true,
+	}
+	return ServiceConfigMapToModel(ctx, config, transformCtx, nil)
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
+)
+
+var serviceDataSourceType string = "data.ioriver_service"
+
+func TestAccIORiverServiceDataSource_Basic(t *testing.T) {
+	certId := os.Getenv("IORIVER_TEST_CERT_ID")
+	rndName := generateRandomResourceName()
+	resourceName := serviceResourceType + "." + rndName
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckV2(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServiceDataSourceConfig(rndName, certId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(serviceDataSourceType+".by_id", "cname", resourceName, "cname"),
+					resource.TestCheckResourceAttrPair(serviceDataSourceType+".by_id", "config.uuid", resourceName, "config.uuid"),
+					resource.TestCheckResourceAttrPair(serviceDataSourceType+".by_name", "id", resourceName, "id"),
+					resource.TestCheckResourceAttr(serviceDataSourceType+".by_name", "certificate", certId),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckServiceDataSourceConfig(resourceName string, certId string) string {
+	return testAccCheckServiceConfigBasic(resourceName, certId) + fmt.Sprintf(`
+
+data "ioriver_service" "by_id" {
+	id = %[1]s.%[2]s.id
+}
+
+data "ioriver_service" "by_name" {
+	name = %[1]s.%[2]s.name
+}`, serviceResourceType, resourceName)
+}
+
+func TestServiceDataSource_ConfigMatchesResourceModel(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	serviceId := server.AddService("svc", "cert-id", map[string]interface{}{
+		"domains": []interface{}{},
+		"origins": []interface{}{},
+	})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	id, err := findServiceIdByName(ctx, client, "svc")
+	if err != nil || id != serviceId {
+		t.Fatalf("expected to find service %s by name, got %q, %v", serviceId, id, err)
+	}
+	if _, err := findServiceIdByName(ctx, client, "missing"); err == nil {
+		t.Fatal("expected an error for a missing service")
+	}
+
+	service, err := GetServiceWithConfig(ctx, client, id)
+	if err != nil {
+		t.Fatalf("failed to read service: %s", err)
+	}
+	data, err := serviceToDataSourceModel(ctx, service)
+	if err != nil {
+		t.Fatalf("failed to convert service: %s", err)
+	}
+
+	// the decoded config must fit the data source schema
+	schemaResp := datasource.SchemaResponse{}
+	(&ServiceDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("failed to set data source state: %v", diags)
+	}
+	if data.Certificate.ValueString() != "cert-id" || data.Cname.ValueString() == "" {
+		t.Fatalf("unexpected data source model: %+v", data)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -19,6 +20,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ServiceProviderResource{}
 var _ resource.ResourceWithImportState = &ServiceProviderResource{}
+var _ ScopedResource = &ServiceProviderResource{}
+var _ AsyncResource = &ServiceProviderResource{}
 
 func NewServiceProviderResource() resource.Resource {
 	return &ServiceProviderResource{}
@@ -30,22 +33,23 @@ type ServiceProviderResourceId struct {
 }
 
 type ServiceProviderResource struct {
-	client *ioriver.IORiverClient
+	client *Client
 }
 
 type ServiceProviderResourceModel struct {
-	Id                 types.String `tfsdk:"id"`
-	Service            types.String `tfsdk:"service"`
-	AccountProvider    types.String `tfsdk:"account_provider"`
-	IsUnmanaged        types.Bool   `tfsdk:"is_unmanaged"`
-	CName              types.String `tfsdk:"cname"`
-	DisplayName        types.String `tfsdk:"display_name"`
-	ProviderCustomData types.String `tfsdk:"provider_custom_data"`
-	IsFailed           types.Bool   `tfsdk:"is_failed"`
-	Status             types.String `tfsdk:"status"`
-	StatusDetails      types.String `tfsdk:"status_details"`
-	Restored           types.Bool   `tfsdk:"restored"`
-	Name               types.String `tfsdk:"name"`
+	Id                 types.String   `tfsdk:"id"`
+	Service            types.String   `tfsdk:"service"`
+	AccountProvider    types.String   `tfsdk:"account_provider"`
+	IsUnmanaged        types.Bool     `tfsdk:"is_unmanaged"`
+	CName              types.String   `tfsdk:"cname"`
+	DisplayName        types.String   `tfsdk:"display_name"`
+	ProviderCustomData types.String   `tfsdk:"provider_custom_data"`
+	IsFailed           types.Bool     `tfsdk:"is_failed"`
+	Status             types.String   `tfsdk:"status"`
+	StatusDetails      types.String   `tfsdk:"status_details"`
+	Restored           types.Bool     `tfsdk:"restored"`
+	Name               types.String   `tfsdk:"name"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *ServiceProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -124,6 +128,13 @@ func (r *ServiceProviderResource) Schema(ctx context.Context, req resource.Schem
 				Computed:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -141,6 +152,15 @@ func (r *ServiceProviderResource) Create(ctx context.Context, req resource.Creat
 	var data ServiceProviderResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	newData := resourceCreate(r.client, ctx, req, resp, r, data, false)
 	if newData == nil {
 		return
@@ -169,6 +189,14 @@ func (r *ServiceProviderResource) Update(ctx context.Context, req resource.Updat
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
 	newData := resourceUpdate(r.client, ctx, req, resp, r, data)
 	if newData == nil {
@@ -187,6 +215,12 @@ func (r *ServiceProviderResource) Delete(ctx context.Context, req resource.Delet
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	resourceDelete(r.client, ctx, req, resp, r, data)
 }
 
@@ -197,49 +231,63 @@ func (r *ServiceProviderResource) ImportState(ctx context.Context, req resource.
 
 // ------- Implement base Resource API ---------
 
-func (ServiceProviderResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
-	newSp, error := client.CreateServiceProvider(newObj.(ioriver.ServiceProvider))
-	if error != nil {
-		return newSp, error
-	}
+func (ServiceProviderResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
+	return callCreate(ctx, client, func() (*ioriver.ServiceProvider, error) {
+		return client.CreateServiceProvider(newObj.(ioriver.ServiceProvider))
+	})
+}
 
-	// Wait for the service provider to become active
-	// If we don't wait and will try to create a traffic policy, it will fail on validation
-	// This operation is performed under the global lock, so it blocks other resources creation.
-	timeout := 60 * time.Minute
-	interval := 10 * time.Second
-	deadline := time.Now().Add(timeout)
-
-	for {
-		newSp, error = client.GetServiceProvider(newSp.Service, newSp.Id)
-		if error == nil {
-			tflog.Info(ctx, fmt.Sprintf("Current Serivce-Provider status: %s", newSp.Status))
-			if newSp.Status == "Active" {
-				break
-			}
+// Wait for the service provider to become active
+// If we don't wait and will try to create a traffic policy, it will fail on validation.
+// The wait is bounded by the create timeout of the resource and runs outside of the
+// service lock scope, so other modifications of the same service can proceed meanwhile.
+func (ServiceProviderResource) waitReady(ctx context.Context, client *Client, obj interface{}, data interface{}) (interface{}, error) {
+	newSp := obj.(*ioriver.ServiceProvider)
+	serviceId, serviceProviderId := newSp.Service, newSp.Id
+	start := time.Now()
+	lastStatus, lastDetails := "", ""
+
+	err := waitUntil(ctx, client, "service provider to become active", func() (bool, error) {
+		sp, err := callIdempotent(ctx, client, func() (*ioriver.ServiceProvider, error) {
+			return client.GetServiceProvider(serviceId, serviceProviderId)
+		})
+		if err != nil {
+			return false, err
 		}
+		newSp = sp
 
-		if time.Now().After(deadline) {
-			break
+		if newSp.Status != lastStatus || newSp.StatusDetails != lastDetails {
+			lastStatus, lastDetails = newSp.Status, newSp.StatusDetails
+			tflog.Info(ctx, fmt.Sprintf("Service-Provider %s status: %s (%s), elapsed %s",
+				serviceProviderId, newSp.Status, newSp.StatusDetails, time.Since(start).Round(time.Second)))
 		}
-		time.Sleep(interval)
-	}
+		if newSp.IsFailed {
+			return false, &StateError{Status: newSp.Status, Reason: newSp.StatusDetails}
+		}
+		return newSp.Status == "Active", nil
+	})
 
-	return newSp, error
+	return newSp, err
 }
 
-func (ServiceProviderResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
+func (ServiceProviderResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
 	resourceId := id.(ServiceProviderResourceId)
-	return client.GetServiceProvider(resourceId.serviceId, resourceId.serviceProviderId)
+	return callIdempotent(ctx, client, func() (*ioriver.ServiceProvider, error) {
+		return client.GetServiceProvider(resourceId.serviceId, resourceId.serviceProviderId)
+	})
 }
 
-func (ServiceProviderResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
-	return client.UpdateServiceProvider(obj.(ioriver.ServiceProvider))
+func (ServiceProviderResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
+	return callIdempotent(ctx, client, func() (*ioriver.ServiceProvider, error) {
+		return client.UpdateServiceProvider(obj.(ioriver.ServiceProvider))
+	})
 }
 
-func (ServiceProviderResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
+func (ServiceProviderResource) delete(ctx context.Context, client *Client, id interface{}) error {
 	resourceId := id.(ServiceProviderResourceId)
-	return client.DeleteServiceProvider(resourceId.serviceId, resourceId.serviceProviderId, "disconnect")
+	return callDelete(ctx, client, func() error {
+		return client.DeleteServiceProvider(resourceId.serviceId, resourceId.serviceProviderId, "disconnect")
+	})
 }
 
 func (ServiceProviderResource) getId(data interface{}) interface{} {
@@ -249,6 +297,11 @@ func (ServiceProviderResource) getId(data interface{}) interface{} {
 	return ServiceProviderResourceId{serviceProviderId, serviceId}
 }
 
+func (ServiceProviderResource) lockScope(data interface{}) string {
+	d := data.(ServiceProviderResourceModel)
+	return ServiceLockScope(d.Service.ValueString())
+}
+
 // Convert ServiceProvider resource to ServiceProvider API object
 func (ServiceProviderResource) resourceToObj(ctx context.Context, data interface{}) (interface{}, error) {
 	d := data.(ServiceProviderResourceModel)
@@ -272,6 +325,7 @@ func (ServiceProviderResource) resourceToObj(ctx context.Context, data interface
 // Convert ServiceProvider API object to ServiceProvider resource
 func (ServiceProviderResource) objToResource(ctx context.Context, obj interface{}, data interface{}) (interface{}, error) {
 	serviceProvider := obj.(*ioriver.ServiceProvider)
+	d := data.(ServiceProviderResourceModel)
 
 	return ServiceProviderResourceModel{
 		Id:                 types.StringValue(serviceProvider.Id),
@@ -286,5 +340,6 @@ func (ServiceProviderResource) objToResource(ctx context.Context, obj interface{
 		StatusDetails:      types.StringValue(serviceProvider.StatusDetails),
 		Restored:           types.BoolValue(serviceProvider.Restored),
 		Name:               types.StringValue(serviceProvider.Name),
+		Timeouts:           d.Timeouts,
 	}, nil
 }
@@ -1,13 +1,17 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	ioriver "github.com/ioriver/ioriver-go"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
 	"golang.org/x/exp/slices"
 )
 
@@ -29,17 +33,17 @@ type TestedServiceProvider struct {
 	TestedObj[ioriver.ServiceProvider]
 }
 
-func (TestedServiceProvider) Get(client *ioriver.IORiverClient, id string) (*ioriver.ServiceProvider, error) {
+func (TestedServiceProvider) Get(client *Client, id string) (*ioriver.ServiceProvider, error) {
 	serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
 	return client.GetServiceProvider(serviceId, id)
 }
 
-func (TestedServiceProvider) List(client *ioriver.IORiverClient) ([]ioriver.ServiceProvider, error) {
+func (TestedServiceProvider) List(client *Client) ([]ioriver.ServiceProvider, error) {
 	serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
 	return client.ListServiceProviders(serviceId)
 }
 
-func (TestedServiceProvider) Delete(client *ioriver.IORiverClient, object ioriver.ServiceProvider, excludeIds []string) error {
+func (TestedServiceProvider) Delete(client *Client, object ioriver.ServiceProvider, excludeIds []string) error {
 	idx := slices.IndexFunc(excludeIds, func(id string) bool { return id == object.Id })
 	if idx < 0 {
 		serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
@@ -98,3 +102,29 @@ func testAccCheckServiceProviderConfigBasic(rndName string, serviceId string, ac
 		account_provider = ioriver_account_provider.test_account_provider.id
 	  }`, accountProviderToken, rndName, serviceId)
 }
+
+func TestServiceProviderWaitReady_Failed(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	serviceId := server.AddService("svc", "cert-id", nil)
+	spId := server.AddServiceObject(serviceId, "service-providers", map[string]interface{}{
+		"account_provider": "account-provider-id",
+		"status":           "Failed",
+		"status_details":   "Invalid API token",
+		"is_failed":        true,
+	})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+	client.pollInterval = 10 * time.Millisecond
+
+	_, err := ServiceProviderResource{}.waitReady(ctx, client, &ioriver.ServiceProvider{Id: spId, Service: serviceId}, nil)
+	var stateErr *StateError
+	if !errors.As(err, &stateErr) {
+		t.Fatalf("expected a state error, got %v", err)
+	}
+	if stateErr.Status != "Failed" || stateErr.Reason != "Invalid API token" {
+		t.Fatalf("unexpected state error: %+v", stateErr)
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	ioriver "github.com/ioriver/ioriver-go"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServiceProvidersDataSource{}
+
+func NewServiceProvidersDataSource() datasource.DataSource {
+	return &ServiceProvidersDataSource{}
+}
+
+type ServiceProvidersDataSource struct {
+	client *Client
+}
+
+type ServiceProvidersDataSourceModel struct {
+	Service          types.String                          `tfsdk:"service"`
+	Cdn              types.String                          `tfsdk:"cdn"`
+	DisplayName      types.String                          `tfsdk:"display_name"`
+	Ids              []types.String                        `tfsdk:"ids"`
+	ServiceProviders []ServiceProviderDataSourceEntryModel `tfsdk:"service_providers"`
+}
+
+type ServiceProviderDataSourceEntryModel struct {
+	Id              types.String `tfsdk:"id"`
+	AccountProvider types.String `tfsdk:"account_provider"`
+	Cdn             types.String `tfsdk:"cdn"`
+	Name            types.String `tfsdk:"name"`
+	DisplayName     types.String `tfsdk:"display_name"`
+	CName           types.String `tfsdk:"cname"`
+	IsUnmanaged     types.Bool   `tfsdk:"is_unmanaged"`
+	IsFailed        types.Bool   `tfsdk:"is_failed"`
+	Status          types.String `tfsdk:"status"`
+	StatusDetails   types.String `tfsdk:"status_details"`
+}
+
+func (d *ServiceProvidersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_providers"
+}
+
+func (d *ServiceProvidersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Service providers data source. Lists the service providers of a service, optionally filtered by CDN or display name.",
+
+		Attributes: map[string]schema.Attribute{
+			"service": schema.StringAttribute{
+				MarkdownDescription: "The id of the service",
+				Required:            true,
+			},
+			"cdn": schema.StringAttribute{
+				MarkdownDescription: "Only return service providers of this CDN, e.g. fastly, cloudflare",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(ProviderNames...),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Only return service providers with this display name",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching service providers",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"service_providers": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching service providers, ordered by display name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "ServiceProvider identifier",
+							Computed:            true,
+						},
+						"account_provider": schema.StringAttribute{
+							MarkdownDescription: "The account provider assigned to the service",
+							Computed:            true,
+						},
+						"cdn": schema.StringAttribute{
+							MarkdownDescription: "The CDN of the service provider, e.g. fastly, cloudflare",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the provider, e.g. Fastly, Cloudflare, etc.",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "Display name of the ServiceProvider",
+							Computed:            true,
+						},
+						"cname": schema.StringAttribute{
+							MarkdownDescription: "CName of the ServiceProvider",
+							Computed:            true,
+						},
+						"is_unmanaged": schema.BoolAttribute{
+							MarkdownDescription: "Is this an unmanaged ServiceProvider",
+							Computed:            true,
+						},
+						"is_failed": schema.BoolAttribute{
+							MarkdownDescription: "An indicator of whether the ServiceProvider is in a failed state",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "ServiceProvider status, e.g., Active, Deploying, etc.",
+							Computed:            true,
+						},
+						"status_details": schema.StringAttribute{
+							MarkdownDescription: "ServiceProvider detailed status",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure data source and retrieve API client
+func (d *ServiceProvidersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	client := ConfigureDataSourceBase(ctx, req, resp)
+	if client == nil {
+		return
+	}
+	d.client = client
+}
+
+// Read ServiceProviders data source
+func (d *ServiceProvidersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServiceProvidersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceId := data.Service.ValueString()
+	serviceProviders, err := callIdempotent(ctx, d.client, func() ([]ioriver.ServiceProvider, error) {
+		return d.client.ListServiceProviders(serviceId)
+	})
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, nil, "Client Error", "Unable to list service providers", err)
+		return
+	}
+
+	data.Ids = []types.String{}
+	data.ServiceProviders = []ServiceProviderDataSourceEntryModel{}
+	for _, sp := range filterServiceProviders(serviceProviders, data.Cdn, data.DisplayName) {
+		data.Ids = append(data.Ids, types.StringValue(sp.Id))
+		data.ServiceProviders = append(data.ServiceProviders, ServiceProviderDataSourceEntryModel{
+			Id:              types.StringValue(sp.Id),
+			AccountProvider: types.StringValue(sp.AccountProvider),
+			Cdn:             types.StringValue(ProviderNamesMapBackendToHCL[sp.Name]),
+			Name:            types.StringValue(sp.Name),
+			DisplayName:     types.StringValue(sp.DisplayName),
+			CName:           types.StringValue(sp.CName),
+			IsUnmanaged:     types.BoolValue(sp.IsUnmanaged),
+			IsFailed:        types.BoolValue(sp.IsFailed),
+			Status:          types.StringValue(sp.Status),
+			StatusDetails:   types.StringValue(sp.StatusDetails),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterServiceProviders returns the service providers matching the given CDN and
+// display name, ordered by display name
+func filterServiceProviders(serviceProviders []ioriver.ServiceProvider, cdn types.String, displayName types.String) []ioriver.ServiceProvider {
+	result := []ioriver.ServiceProvider{}
+	for _, sp := range serviceProviders {
+		if !cdn.IsNull() && ProviderNamesMapBackendToHCL[sp.Name] != cdn.ValueString() {
+			continue
+		}
+		if !displayName.IsNull() && sp.DisplayName != displayName.ValueString() {
+			continue
+		}
+		result = append(result, sp)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].DisplayName != result[j].DisplayName {
+			return result[i].DisplayName < result[j].DisplayName
+		}
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
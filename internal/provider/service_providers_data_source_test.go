@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	ioriver "github.com/ioriver/ioriver-go"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
+)
+
+var serviceProvidersDataSourceType string = "data.ioriver_service_providers"
+
+func TestAccIORiverServiceProvidersDataSource_Basic(t *testing.T) {
+	serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
+	fastlyToken := os.Getenv("IORIVER_TEST_FASTLY_API_TOKEN")
+	rndName := generateRandomResourceName()
+	resourceName := spResourceType + "." + rndName
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckV2(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServiceProvidersDataSourceConfig(rndName, serviceId, fastlyToken),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttrPair(serviceProvidersDataSourceType+".fastly", "ids.*", resourceName, "id"),
+					resource.TestCheckResourceAttr(serviceProvidersDataSourceType+".fastly", "service_providers.0.cdn", "fastly"),
+					resource.TestCheckResourceAttrSet(serviceProvidersDataSourceType+".fastly", "service_providers.0.cname"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckServiceProvidersDataSourceConfig(rndName string, serviceId string, fastlyToken string) string {
+	return testAccCheckServiceProviderConfigBasic(rndName, serviceId, fastlyToken) + fmt.Sprintf(`
+
+	data "ioriver_service_providers" "fastly" {
+		service    = "%s"
+		cdn        = "fastly"
+		depends_on = [%s.%s]
+	}`, serviceId, spResourceType, rndName)
+}
+
+func TestFilterServiceProviders(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	serviceId := server.AddService("svc", "cert-id", nil)
+	fastly := server.AddServiceObject(serviceId, "service-providers", map[string]interface{}{
+		"name": "Fastly", "display_name": "edge-a",
+	})
+	cloudfront := server.AddServiceObject(serviceId, "service-providers", map[string]interface{}{
+		"name": "Cloudfront", "display_name": "edge-b",
+	})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	serviceProviders, err := callIdempotent(ctx, client, func() ([]ioriver.ServiceProvider, error) {
+		return client.ListServiceProviders(serviceId)
+	})
+	if err != nil {
+		t.Fatalf("failed to list service providers: %s", err)
+	}
+
+	cases := []struct {
+		cdn         types.String
+		displayName types.String
+		expected    []string
+	}{
+		{types.StringNull(), types.StringNull(), []string{fastly, cloudfront}},
+		{types.StringValue("cloudfront"), types.StringNull(), []string{cloudfront}},
+		{types.StringNull(), types.StringValue("edge-a"), []string{fastly}},
+		{types.StringValue("cloudfront"), types.StringValue("edge-a"), []string{}},
+	}
+	for _, c := range cases {
+		ids := []string{}
+		for _, sp := range filterServiceProviders(serviceProviders, c.cdn, c.displayName) {
+			ids = append(ids, sp.Id)
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(c.expected) {
+			t.Errorf("cdn %s, display name %s: expected %v, got %v", c.cdn, c.displayName, c.expected, ids)
+		}
+	}
+}
@@ -4,22 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	ioriver "github.com/ioriver/ioriver-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ServiceResource{}
 var _ resource.ResourceWithImportState = &ServiceResource{}
 var _ resource.ResourceWithValidateConfig = &ServiceResource{}
+var _ resource.ResourceWithModifyPlan = &ServiceResource{}
+var _ ScopedResource = &ServiceResource{}
 
 func NewServiceResource() resource.Resource {
 	return &ServiceResource{}
@@ -27,11 +36,13 @@ func NewServiceResource() resource.Resource {
 
 type ServiceResourceId = string
 type ServiceResource struct {
-	client *ioriver.IORiverClient
+	client *Client
 }
 
 const (
 	CurrentTransformCtxPrivateKeyName = "current_transform_ctx"
+	// ConfigVersionPrivateKeyName holds the config version which was last read
+	ConfigVersionPrivateKeyName = "config_version"
 )
 
 type ServiceTransformContext struct {
@@ -55,13 +66,23 @@ type ServiceTransformContext struct {
 }
 
 type ServiceResourceModel struct {
-	Id                 types.String             `tfsdk:"id"`
-	Name               types.String             `tfsdk:"name"`
-	Description        types.String             `tfsdk:"description"`
-	Cname              types.String             `tfsdk:"cname"`
-	Certificate        types.String             `tfsdk:"certificate"`
-	Config             *ServiceConfigModel      `tfsdk:"config"`
-	updateTransformCtx *ServiceTransformContext // No tfsdk tag - not in schema!
+	Id                  types.String             `tfsdk:"id"`
+	Name                types.String             `tfsdk:"name"`
+	Description         types.String             `tfsdk:"description"`
+	Cname               types.String             `tfsdk:"cname"`
+	Certificate         types.String             `tfsdk:"certificate"`
+	Certificates        types.List               `tfsdk:"certificates"`
+	Config              *ServiceConfigModel      `tfsdk:"config"`
+	PinnedConfigVersion types.Int64              `tfsdk:"pinned_config_version"`
+	Timeouts            timeouts.Value           `tfsdk:"timeouts"`
+	updateTransformCtx  *ServiceTransformContext // No tfsdk tag - not in schema!
+	// rollback is set when the update should roll the config back to PinnedConfigVersion
+	rollback bool
+	// configVersion is the config version stored in private state
+	configVersion int
+	// certificatesUnlisted is set when the API has no certificates list for the
+	// service, so only its default certificate was read
+	certificatesUnlisted bool
 }
 
 func (r *ServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -88,10 +109,21 @@ func (r *ServiceResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Optional:            true,
 			},
 			"certificate": schema.StringAttribute{
-				MarkdownDescription: "ID of the certificate to be used with the service",
-				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+				MarkdownDescription: "ID of the certificate to be used with the service. Use `certificates` instead for services with several certificates. " +
+					"Changing it updates the service in place, after checking that the new certificate covers the domains of the service",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("certificates")),
+				},
+			},
+			"certificates": schema.ListAttribute{
+				MarkdownDescription: "IDs of the certificates to be used with the service, for services serving hostnames which are not covered by a single certificate. " +
+					"The first certificate is the default certificate of the service. Domains choose their certificate with `config.domains[].certificate`",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
 				},
 			},
 			"cname": schema.StringAttribute{
@@ -106,6 +138,22 @@ func (r *ServiceResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Service configuration",
 				Attributes:          ConfigAttributes(),
 			},
+			"pinned_config_version": schema.Int64Attribute{
+				MarkdownDescription: "Pin the service to a previous config version. Setting or changing it rolls the live config back to that version by posting it as a new version. " +
+					"While pinned, changes of `config` are held back: they are not applied, the live config of the pinned version is read into state, and every plan shows its differences from `config` as an update. " +
+					"Applying that update keeps the service pinned. Remove it to apply `config` again",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -123,6 +171,14 @@ func (r *ServiceResource) Configure(ctx context.Context, req resource.ConfigureR
 func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ServiceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	// This is used during this flow for storing adapting fields
 	data.updateTransformCtx = &ServiceTransformContext{
@@ -152,6 +208,7 @@ func (r *ServiceResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	resp.Private.SetKey(ctx, CurrentTransformCtxPrivateKeyName, cfgJson)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ConfigVersionPrivateKeyName, configVersionJson(newData))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newData)...)
 }
 
@@ -180,11 +237,27 @@ func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, re
 		}
 	}
 
+	configVersionBytes, diags := req.Private.GetKey(ctx, ConfigVersionPrivateKeyName)
+	resp.Diagnostics.Append(diags...)
+	priorConfigVersion, err := parseConfigVersion(configVersionBytes)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to unmarshal config version",
+			fmt.Sprintf("Error: %s", err))
+		return
+	}
+
 	newData := resourceRead(r.client, ctx, req, resp, r, data)
 	if newData == nil {
 		return
 	}
 
+	// reading back only the default certificate would show a permanent diff
+	if newData.(ServiceResourceModel).certificatesUnlisted && len(data.Certificates.Elements()) > 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("certificates"), "Multiple certificates not supported",
+			fmt.Sprintf("Could not read the certificates of service %s, %s.", data.Id.ValueString(), errServiceCertificatesUnsupported))
+		return
+	}
+
 	// Save transform context in private state
 	cfgJson, err := json.Marshal(data.updateTransformCtx)
 	if err != nil {
@@ -194,7 +267,13 @@ func (r *ServiceResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	resp.Private.SetKey(ctx, CurrentTransformCtxPrivateKeyName, cfgJson)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ConfigVersionPrivateKeyName, configVersionJson(newData))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.reportConfigDrift(ctx, req, resp, priorConfigVersion, newData.(ServiceResourceModel).configVersion)
 }
 
 // Update Service resource
@@ -211,6 +290,11 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// WriteOnly credentials are not stored in state so the plan cannot carry them
 	// from prior state. Re-read them from the raw config and merge by name,
 	// but only inject when credentials_version changed vs state.
@@ -239,6 +323,39 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 	}
 
+	// Certificates which were not known during plan, e.g. replaced with
+	// create_before_destroy, are checked before they are bound to the service
+	if serviceCoverageChanged(data, &stateData) {
+		resp.Diagnostics.Append(serviceCertificateCoverage(ctx, r.client, data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.rollback = !data.PinnedConfigVersion.IsNull() && !data.PinnedConfigVersion.Equal(stateData.PinnedConfigVersion)
+
+	// Only config changed while the service is pinned, there is nothing to send
+	if !data.PinnedConfigVersion.IsNull() && !data.rollback && !serviceFieldsChanged(data, stateData) {
+		raw, err := resolveUnknowns(req.Plan.Raw, req.State.Raw)
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating resource", "Failed to build the state of a pinned service: "+err.Error())
+			return
+		}
+		resp.State.Raw = raw
+		addPinnedConfigWarning(&resp.Diagnostics, data)
+		return
+	}
+
+	// Base the new config version on the version the plan was made from
+	configVersionBytes, diags := req.Private.GetKey(ctx, ConfigVersionPrivateKeyName)
+	resp.Diagnostics.Append(diags...)
+	var err error
+	if data.configVersion, err = parseConfigVersion(configVersionBytes); err != nil {
+		resp.Diagnostics.AddError("Failed to unmarshal config version",
+			fmt.Sprintf("Error: %s", err))
+		return
+	}
+
 	newData := resourceUpdate(r.client, ctx, req, resp, r, data)
 	if newData == nil {
 		return
@@ -253,7 +370,40 @@ func (r *ServiceResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	resp.Private.SetKey(ctx, CurrentTransformCtxPrivateKeyName, cfgJson)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ConfigVersionPrivateKeyName, configVersionJson(newData))...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newData)...)
+
+	if !data.PinnedConfigVersion.IsNull() && !resp.Diagnostics.HasError() {
+		// The live config is the pinned version rather than the planned config. Terraform
+		// requires the planned values in state, the next refresh reads the live config
+		// back so plans show the differences.
+		raw, err := resolveUnknowns(req.Plan.Raw, resp.State.Raw)
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating resource", "Failed to build the state of a pinned service: "+err.Error())
+			return
+		}
+		resp.State.Raw = raw
+		addPinnedConfigWarning(&resp.Diagnostics, data)
+	}
+}
+
+// serviceFieldsChanged reports whether a service field other than config changed
+func serviceFieldsChanged(planData ServiceResourceModel, stateData ServiceResourceModel) bool {
+	return !planData.Name.Equal(stateData.Name) ||
+		!planData.Description.Equal(stateData.Description) ||
+		!planData.Certificate.Equal(stateData.Certificate) ||
+		!planData.Certificates.Equal(stateData.Certificates)
+}
+
+// addPinnedConfigWarning warns that config is not applied to a pinned service
+func addPinnedConfigWarning(diags *diag.Diagnostics, data ServiceResourceModel) {
+	summary := fmt.Sprintf("Service is pinned to config version %d", data.PinnedConfigVersion.ValueInt64())
+	if data.rollback {
+		summary = fmt.Sprintf("Service config rolled back to version %d", data.PinnedConfigVersion.ValueInt64())
+	}
+	diags.AddAttributeWarning(path.Root("pinned_config_version"), summary,
+		"Changes of config are held back while the service is pinned. Plans show the differences between the live config and the configuration until "+
+			"pinned_config_version is removed, which applies config again.")
 }
 
 // Delete Service resource
@@ -264,6 +414,12 @@ func (r *ServiceResource) Delete(ctx context.Context, req resource.DeleteRequest
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	resourceDelete(r.client, ctx, req, resp, r, data)
 }
 
@@ -272,6 +428,81 @@ func (r *ServiceResource) ImportState(ctx context.Context, req resource.ImportSt
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+// configVersionJson returns the config version of a service model, as stored in private state
+func configVersionJson(data interface{}) []byte {
+	return []byte(strconv.Itoa(data.(ServiceResourceModel).configVersion))
+}
+
+// parseConfigVersion parses a config version stored in private state, 0 if none is stored
+func parseConfigVersion(data []byte) (int, error) {
+	version := 0
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &version); err != nil {
+			return 0, err
+		}
+	}
+	return version, nil
+}
+
+// maximal number of changed paths listed in a drift warning
+const maxDriftPaths = 25
+
+// formatConfigPaths lists config paths for a warning, up to maxDriftPaths
+func formatConfigPaths(paths []string) string {
+	var result string
+	for i, p := range paths {
+		if i == maxDriftPaths {
+			result += fmt.Sprintf("\n  ... and %d more", len(paths)-maxDriftPaths)
+			break
+		}
+		if p == "" {
+			p = "(the whole config)"
+		}
+		result += "\n  - " + p
+	}
+	return result
+}
+
+// reportConfigDrift warns about config changes made outside Terraform, i.e. in new
+// config versions which were not created by this resource.
+func (r *ServiceResource) reportConfigDrift(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse, priorVersion int, version int) {
+	if priorVersion == 0 || version == priorVersion {
+		// without a new version, differences come from normalizing the config
+		return
+	}
+
+	configPath := tftypes.NewAttributePath().WithAttributeName("config")
+	before, _, err := tftypes.WalkAttributePath(req.State.Raw, configPath)
+	if err != nil {
+		return
+	}
+	after, _, err := tftypes.WalkAttributePath(resp.State.Raw, configPath)
+	if err != nil {
+		return
+	}
+	paths := configDiffPaths(before.(tftypes.Value), after.(tftypes.Value))
+	if len(paths) == 0 {
+		return
+	}
+
+	var serviceId types.String
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &serviceId)...)
+
+	detail := fmt.Sprintf("The config of service %s changed outside Terraform, from version %d to version %d.", serviceId.ValueString(), priorVersion, version)
+	changes, err := listConfigChanges(ctx, r.client, serviceId.ValueString(), priorVersion)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Failed to list the config versions of service %s: %s", serviceId.ValueString(), err))
+	}
+	for _, change := range changes {
+		detail += "\n  - " + change.String()
+	}
+
+	detail += "\n\nChanged config paths:" + formatConfigPaths(paths)
+	detail += "\n\nApplying the configuration reverts these changes, unless config is updated to match them."
+
+	resp.Diagnostics.AddAttributeWarning(path.Root("config"), "Service config changed outside Terraform", detail)
+}
+
 // mergeWriteOnlyCredentialsFromConfig injects WriteOnly credentials from the raw
 // config into the plan model before the API call. WriteOnly fields (s3_aws_key,
 // s3_aws_secret, log-destination credentials) are null in the plan on Update
@@ -285,20 +516,20 @@ func mergeWriteOnlyCredentialsFromConfig(planData, configData, stateData *Servic
 
 // ------- Implement base Resource API ---------
 
-func (ServiceResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
-	return CreateServiceWithConfig(client, newObj.(ServiceWithConfig))
+func (ServiceResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
+	return CreateServiceWithConfig(ctx, client, newObj.(ServiceWithConfig))
 }
 
-func (ServiceResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
-	return GetServiceWithConfig(client, id.(ServiceResourceId))
+func (ServiceResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
+	return GetServiceWithConfig(ctx, client, id.(ServiceResourceId))
 }
 
-func (ServiceResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
-	return UpdateServiceWithConfig(client, obj.(ServiceWithConfig))
+func (ServiceResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
+	return UpdateServiceWithConfig(ctx, client, obj.(ServiceWithConfig))
 }
 
-func (ServiceResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
-	return DeleteServiceWithConfig(client, id.(ServiceResourceId))
+func (ServiceResource) delete(ctx context.Context, client *Client, id interface{}) error {
+	return DeleteServiceWithConfig(ctx, client, id.(ServiceResourceId))
 }
 
 func (ServiceResource) getId(data interface{}) interface{} {
@@ -306,6 +537,15 @@ func (ServiceResource) getId(data interface{}) interface{} {
 	return d.Id.ValueString()
 }
 
+func (ServiceResource) lockScope(data interface{}) string {
+	d := data.(ServiceResourceModel)
+	if d.Id.IsNull() || d.Id.IsUnknown() {
+		// a service being created doesn't share a config version chain with anything yet
+		return ServiceLockScope("new/" + d.Name.ValueString())
+	}
+	return ServiceLockScope(d.Id.ValueString())
+}
+
 // Convert Service resource to Service API object
 func (ServiceResource) resourceToObj(ctx context.Context, data interface{}) (interface{}, error) {
 	d := data.(ServiceResourceModel)
@@ -333,14 +573,20 @@ func (ServiceResource) resourceToObj(ctx context.Context, data interface{}) (int
 		d.updateTransformCtx.SecurityConfigured = !d.Config.Security.IsNull() && !d.Config.Security.IsUnknown()
 	}
 
-	certificate := d.Certificate.ValueString()
+	certificates, diags := serviceCertificatesFromModel(ctx, d)
+	if diags.HasError() {
+		return nil, fmt.Errorf("resourceToObj: failed to convert certificates: %v", diags.Errors())
+	}
 
 	return ServiceWithConfig{
-		Id:           d.Id.ValueString(),
-		Name:         d.Name.ValueString(),
-		Description:  d.Description.ValueString(),
-		Certificates: []string{certificate},
-		Config:       configMap,
+		Id:            d.Id.ValueString(),
+		Name:          d.Name.ValueString(),
+		Description:   d.Description.ValueString(),
+		Certificates:  certificates,
+		Config:        configMap,
+		PinnedVersion: int(d.PinnedConfigVersion.ValueInt64()),
+		Rollback:      d.rollback,
+		ConfigVersion: d.configVersion,
 	}, nil
 }
 
@@ -375,16 +621,49 @@ func (ServiceResource) objToResource(ctx context.Context, obj interface{}, data
 		tflog.Debug(ctx, "[objToResource] ✓ configModel populated")
 	}
 
+	certificate, certificates, diags := serviceCertificatesToModel(ctx, service.Certificates, d)
+	if diags.HasError() {
+		return nil, fmt.Errorf("objToResource: failed to convert certificates: %v", diags.Errors())
+	}
+
 	return ServiceResourceModel{
-		Id:          types.StringValue(service.Id),
-		Name:        types.StringValue(service.Name),
-		Description: types.StringValue(service.Description),
-		Certificate: types.StringValue(service.Certificates[0]),
-		Cname:       types.StringValue(service.Cname),
-		Config:      configModel,
+		Id:           types.StringValue(service.Id),
+		Name:         types.StringValue(service.Name),
+		Description:  types.StringValue(service.Description),
+		Certificate:  certificate,
+		Certificates: certificates,
+		Cname:        types.StringValue(service.Cname),
+		Config:       configModel,
+		// not returned by the API
+		PinnedConfigVersion:  d.PinnedConfigVersion,
+		Timeouts:             d.Timeouts,
+		configVersion:        service.ConfigVersion,
+		certificatesUnlisted: service.defaultCertificateOnly,
 	}, nil
 }
 
+// ModifyPlan checks that the domains of the service are covered by its certificates
+func (r *ServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPinnedConfigVersionOnCreate(ctx, req, resp)
+	checkServiceCertificateCoverage(ctx, r.client, req, resp)
+}
+
+// checkPinnedConfigVersionOnCreate fails the plan of a new service which is
+// pinned to a config version
+func checkPinnedConfigVersionOnCreate(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var pinned types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("pinned_config_version"), &pinned)...)
+	if pinned.IsNull() {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(path.Root("pinned_config_version"), "Invalid pinned config version",
+		"A new service has no previous config versions, set pinned_config_version only after the service is created")
+}
+
 // ValidateConfig runs cross-field validation that cannot be expressed with
 // schema-level validators alone (e.g. field_key required for collection fields).
 // It is called by the framework automatically on every plan and apply.
@@ -395,6 +674,8 @@ func (r *ServiceResource) ValidateConfig(ctx context.Context, req resource.Valid
 		return
 	}
 
+	validateDomainCertificates(ctx, data, &resp.Diagnostics)
+
 	// --- Security (WAF custom rules + rate limit) ---
 	var secPtr *SecurityModel
 	if !data.Config.Security.IsNull() && !data.Config.Security.IsUnknown() {
@@ -1,15 +1,24 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
-	ioriver "github.com/ioriver/ioriver-go"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
 	"golang.org/x/exp/slices"
 )
 
@@ -30,15 +39,15 @@ type TestedService struct {
 	TestedObj[ServiceWithConfig]
 }
 
-func (TestedService) Get(client *ioriver.IORiverClient, id string) (*ServiceWithConfig, error) {
-	return GetServiceWithConfig(client, id)
+func (TestedService) Get(client *Client, id string) (*ServiceWithConfig, error) {
+	return GetServiceWithConfig(context.Background(), client, id)
 }
 
-func (TestedService) List(client *ioriver.IORiverClient) ([]ServiceWithConfig, error) {
-	return ListServicesWithConfig(client)
+func (TestedService) List(client *Client) ([]ServiceWithConfig, error) {
+	return ListServicesWithConfig(context.Background(), client)
 }
 
-func (TestedService) Delete(client *ioriver.IORiverClient, object ServiceWithConfig, excludeIds []string) error {
+func (TestedService) Delete(client *Client, object ServiceWithConfig, excludeIds []string) error {
 	idx := slices.IndexFunc(excludeIds, func(id string) bool { return id == object.Id })
 	if idx < 0 {
 		return client.DeleteService(object.Id)
@@ -116,6 +125,186 @@ func TestAccIORiverService_Basic(t *testing.T) {
 	})
 }
 
+func TestAccIORiverService_PinnedConfigVersion(t *testing.T) {
+	certId := os.Getenv("IORIVER_TEST_CERT_ID")
+	rndName := generateRandomResourceName()
+	resourceName := serviceResourceType + "." + rndName
+
+	pinned := func(config string) string {
+		return strings.Replace(config, `description = "A generic service"`, `description = "A generic service"
+	pinned_config_version = 1`, 1)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckV2(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServiceConfigWithoutProtocol(rndName, certId),
+			},
+			{
+				// roll back to the first version, which matches the configuration
+				Config: pinned(testAccCheckServiceConfigWithoutProtocol(rndName, certId)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "pinned_config_version", "1"),
+				),
+			},
+			{
+				// config changes are held back while pinned, the live config is read
+				// back so the next plan shows the differences
+				Config: pinned(testAccCheckServiceConfigWithProtocol(rndName, certId, true, false, false)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "pinned_config_version", "1"),
+				),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestCheckPinnedConfigVersionOnCreate(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := fwresource.SchemaResponse{}
+	(&ServiceResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	serviceSchema := schemaResp.Schema
+	objectType := serviceSchema.Type().TerraformType(ctx)
+
+	check := func(stateNull bool, pinned *int64) fwresource.ModifyPlanResponse {
+		plan := tfsdk.Plan{Schema: serviceSchema, Raw: tftypes.NewValue(objectType, nil)}
+		diags := plan.SetAttribute(ctx, path.Root("name"), "svc")
+		if pinned != nil {
+			diags.Append(plan.SetAttribute(ctx, path.Root("pinned_config_version"), *pinned)...)
+		}
+		if diags.HasError() {
+			t.Fatalf("failed to build plan: %v", diags)
+		}
+		state := tfsdk.State{Schema: serviceSchema, Raw: tftypes.NewValue(objectType, nil)}
+		if !stateNull {
+			state.Raw = plan.Raw.Copy()
+		}
+		resp := fwresource.ModifyPlanResponse{Plan: plan}
+		checkPinnedConfigVersionOnCreate(ctx, fwresource.ModifyPlanRequest{Plan: plan, State: state}, &resp)
+		return resp
+	}
+
+	version := int64(1)
+	if resp := check(true, nil); resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if resp := check(false, &version); resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors for an existing service: %v", resp.Diagnostics)
+	}
+	resp := check(true, &version)
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected a single error, got %v", resp.Diagnostics)
+	}
+	if withPath := resp.Diagnostics[0].(diag.DiagnosticWithPath); !withPath.Path().Equal(path.Root("pinned_config_version")) {
+		t.Fatalf("unexpected error path %s", withPath.Path())
+	}
+}
+
+func TestUpdateServiceWithConfig_PinnedVersion(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	serviceId := server.AddService("svc", "cert-id", map[string]interface{}{"marker": "v1"})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	update := func(service ServiceWithConfig) {
+		t.Helper()
+		service.Id = serviceId
+		service.Name = "svc"
+		if _, err := UpdateServiceWithConfig(ctx, client, service); err != nil {
+			t.Fatalf("failed to update service: %s", err)
+		}
+	}
+
+	update(ServiceWithConfig{Config: map[string]interface{}{"marker": "v2"}})
+	// rolling back posts the pinned version on top of the current version
+	update(ServiceWithConfig{Config: map[string]interface{}{"marker": "v2"}, PinnedVersion: 1, Rollback: true})
+	// while pinned, the config is not posted
+	update(ServiceWithConfig{Config: map[string]interface{}{"marker": "v4"}, PinnedVersion: 1})
+
+	versions, err := ListServiceConfigVersions(ctx, client, serviceId)
+	if err != nil {
+		t.Fatalf("failed to list config versions: %s", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("expected 3 config versions, got %d", len(versions))
+	}
+	current := versions[0]
+	if current.Version != 3 || current.ParentVersion != 2 || current.ConfigJSON["marker"] != "v1" || current.Description != "Rollback to version 1" {
+		t.Fatalf("unexpected rollback version: %+v", current)
+	}
+}
+
+func TestUpdateServiceWithConfig_Conflict(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	serviceId := server.AddService("svc", "cert-id", map[string]interface{}{"marker": "v1", "waf": "off"})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	planned, err := GetServiceWithConfig(ctx, client, serviceId)
+	if err != nil {
+		t.Fatalf("failed to read service: %s", err)
+	}
+	if planned.ConfigVersion != 1 {
+		t.Fatalf("expected config version 1, got %d", planned.ConfigVersion)
+	}
+
+	// the config is changed outside Terraform after it was read
+	outside := *planned
+	outside.Config = map[string]interface{}{}
+	for key, value := range planned.Config {
+		outside.Config[key] = value
+	}
+	outside.Config["waf"] = "on"
+	if _, err := UpdateServiceWithConfig(ctx, client, outside); err != nil {
+		t.Fatalf("failed to update service: %s", err)
+	}
+
+	planned.Config = map[string]interface{}{"marker": "v2", "waf": "off"}
+	_, err = UpdateServiceWithConfig(ctx, client, *planned)
+	var conflictErr *ConfigConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("expected a config conflict, got %v", err)
+	}
+	if conflictErr.ExpectedVersion != 1 || conflictErr.CurrentVersion != 2 || len(conflictErr.Changes) != 1 {
+		t.Fatalf("unexpected conflict: %+v", conflictErr)
+	}
+	change := conflictErr.Changes[0]
+	if change.Version != 2 || change.CreatedBy != mockapi.MockUser || fmt.Sprint(change.Sections) != "[config.security]" {
+		t.Fatalf("unexpected conflicting change: %+v", change)
+	}
+
+	// the outside change is kept
+	current, err := GetServiceWithConfig(ctx, client, serviceId)
+	if err != nil {
+		t.Fatalf("failed to read service: %s", err)
+	}
+	if current.ConfigVersion != 2 || current.Config["waf"] != "on" {
+		t.Fatalf("expected the outside change to be kept, got version %d: %v", current.ConfigVersion, current.Config)
+	}
+
+	diags := diag.Diagnostics{}
+	addAPIErrorDiagnostics(ctx, &diags, nil, "Error updating resource", "Could not update resource", conflictErr)
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Service config conflict" ||
+		!strings.Contains(diags.Errors()[0].Detail(), "version 2 by "+mockapi.MockUser) {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
 func TestAccIORiverService_WithOrigins(t *testing.T) {
 	var service ServiceWithConfig
 	var testedObj TestedService
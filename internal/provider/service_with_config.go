@@ -1,7 +1,14 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ioriver/ioriver-go"
+	"golang.org/x/exp/slices"
 )
 
 type ServiceWithConfig struct {
@@ -13,9 +20,82 @@ type ServiceWithConfig struct {
 	ServiceUid   string                 `json:"service_uid,omitempty"`
 	Cname        string                 `json:"cname,omitempty"`
 	Config       map[string]interface{} `json:"service_config,omitempty"` // Not returned by API, populated separately
+	// PinnedVersion is the config version the service is pinned to. While pinned,
+	// updates don't post Config. Not returned by API.
+	PinnedVersion int `json:"-"`
+	// Rollback requests posting the config of PinnedVersion as a new version
+	Rollback bool `json:"-"`
+	// ConfigVersion is the version Config was read from. Updates are based on it and
+	// fail with a ConfigConflictError if the service has a newer version.
+	ConfigVersion int `json:"-"`
+	// defaultCertificateOnly is set when Certificates only holds the default
+	// certificate of the service, see ListServicesWithConfig, or when the API has
+	// no certificates list for the service
+	defaultCertificateOnly bool
+}
+
+// serviceCertificates is the list of certificates bound to a service. The
+// ioriver-go client only exposes the default certificate of a service, the list
+// is read and set with apiRequest. It is only set for services with several
+// certificates, the default certificate is set with UpdateService.
+type serviceCertificates struct {
+	Certificates []string `json:"certificates"`
+}
+
+// errServiceCertificatesUnsupported is returned when several certificates are
+// set on a service, and the API has no certificates list for services
+var errServiceCertificatesUnsupported = errors.New("services with several certificates are not supported by the API, set a single certificate")
+
+// getServiceCertificates returns all the certificates of a service, the default
+// certificate first. When the API has no certificates list for the service, only
+// the default certificate is returned and listed is false.
+func getServiceCertificates(ctx context.Context, client *Client, id string, defaultCertificate string) (certificates []string, listed bool, err error) {
+	resp, err := callIdempotent(ctx, client, func() (*serviceCertificates, error) {
+		var resp serviceCertificates
+		if err := apiGet(ctx, client, "services/"+id+"/certificates/", &resp); err != nil {
+			return nil, err
+		}
+		return &resp, nil
+	})
+	listed = true
+	if apiErrorStatusCode(err) == http.StatusNotFound {
+		resp, err, listed = &serviceCertificates{}, nil, false
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	certificates = []string{}
+	if defaultCertificate != "" {
+		certificates = append(certificates, defaultCertificate)
+	}
+	for _, id := range resp.Certificates {
+		if !slices.Contains(certificates, id) {
+			certificates = append(certificates, id)
+		}
+	}
+	return certificates, listed, nil
 }
 
-func CreateServiceWithConfig(client *ioriver.IORiverClient, serviceWithConfig ServiceWithConfig) (*ServiceWithConfig, error) {
+// setServiceCertificates replaces the certificates bound to a service. The
+// default certificate of the service is set with UpdateService, and must be one
+// of them.
+func setServiceCertificates(ctx context.Context, client *Client, id string, certificates []string) error {
+	_, err := callIdempotent(ctx, client, func() (*serviceCertificates, error) {
+		var resp serviceCertificates
+		body := serviceCertificates{Certificates: certificates}
+		if err := apiRequest(ctx, client, http.MethodPut, "services/"+id+"/certificates/", body, &resp); err != nil {
+			return nil, err
+		}
+		return &resp, nil
+	})
+	if apiErrorStatusCode(err) == http.StatusNotFound {
+		return errServiceCertificatesUnsupported
+	}
+	return err
+}
+
+func CreateServiceWithConfig(ctx context.Context, client *Client, serviceWithConfig ServiceWithConfig) (*ServiceWithConfig, error) {
 	service := ioriver.Service{
 		Id:          serviceWithConfig.Id,
 		Account:     serviceWithConfig.Account,
@@ -29,95 +109,225 @@ func CreateServiceWithConfig(client *ioriver.IORiverClient, serviceWithConfig Se
 		ConfigJSON: serviceWithConfig.Config,
 	}
 
-	resp, err := client.CreateServiceWithConfig(service, serviceConfig)
+	resp, err := callCreate(ctx, client, func() (*ioriver.Service, error) {
+		return client.CreateServiceWithConfig(service, serviceConfig)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Only the default certificate is set on create
+	if len(serviceWithConfig.Certificates) > 1 {
+		if err := setServiceCertificates(ctx, client, resp.Id, serviceWithConfig.Certificates); err != nil {
+			// don't leave a service with some of its certificates behind
+			if deleteErr := DeleteServiceWithConfig(ctx, client, resp.Id); deleteErr != nil {
+				return nil, fmt.Errorf("failed to set the certificates of service %s: %w, and failed to delete it: %s", resp.Id, err, deleteErr)
+			}
+			return nil, fmt.Errorf("failed to set the certificates of service %s: %w", resp.Id, err)
+		}
+	}
+
 	// Assemble service with service-config
-	return GetServiceWithConfig(client, resp.Id)
+	return GetServiceWithConfig(ctx, client, resp.Id)
 }
 
-func UpdateServiceWithConfig(client *ioriver.IORiverClient, service ServiceWithConfig) (*ServiceWithConfig, error) {
+func UpdateServiceWithConfig(ctx context.Context, client *Client, service ServiceWithConfig) (*ServiceWithConfig, error) {
 	// Retrieve config to use fields for the update
-	serviceConfigResponse, err := client.GetCurrentServiceConfig(service.Id)
+	serviceConfigResponse, err := callIdempotent(ctx, client, func() (*ioriver.ServiceConfig, error) {
+		return client.GetCurrentServiceConfig(service.Id)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Update config - backend uses POST (create) to add a new service config version
-	_, err = client.UpdateServiceConfig(service.Id, ioriver.ServiceConfig{
-		ParentVersion: serviceConfigResponse.Version,
+	parentVersion := serviceConfigResponse.Version
+	if service.ConfigVersion > 0 {
+		parentVersion = service.ConfigVersion
+	}
+
+	newConfig := &ioriver.ServiceConfig{
+		ParentVersion: parentVersion,
 		Description:   service.Description,
 		ConfigJSON:    service.Config,
+	}
+	// While the service is pinned, a config version is only posted to roll back
+	if service.PinnedVersion > 0 {
+		newConfig = nil
+		if service.Rollback {
+			pinned, err := GetServiceConfigVersion(ctx, client, service.Id, service.PinnedVersion)
+			if err != nil {
+				return nil, err
+			}
+			newConfig = &ioriver.ServiceConfig{
+				ParentVersion: parentVersion,
+				Description:   fmt.Sprintf("Rollback to version %d", service.PinnedVersion),
+				ConfigJSON:    pinned.ConfigJSON,
+			}
+		}
+	}
+
+	// don't overwrite changes made since the config was read, nor change the
+	// certificates of the service before knowing the config can be updated
+	if newConfig != nil && parentVersion != serviceConfigResponse.Version {
+		return nil, newConfigConflictError(ctx, client, service.Id, parentVersion, serviceConfigResponse.Version)
+	}
+
+	current, err := callIdempotent(ctx, client, func() (*ioriver.Service, error) {
+		return client.GetService(service.Id)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Update service fields: name, description
+	// Certificates are bound before the config is updated, so new domains are
+	// covered, and unbound after it, so removed domains are not left uncovered.
+	// Services with a single certificate before and after the update only have
+	// their default certificate set, with UpdateService.
+	previousCertificates, listed, err := getServiceCertificates(ctx, client, current.Id, current.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	boundCertificates := previousCertificates
+	certificates := service.Certificates
+	if len(certificates) == 0 {
+		certificates = boundCertificates
+	}
+	if !listed && len(certificates) > 1 {
+		return nil, errServiceCertificatesUnsupported
+	}
+	setList := len(certificates) > 1 || len(previousCertificates) > 1
+	if setList && !slices.Equal(certificates, boundCertificates) {
+		union := slices.Clone(certificates)
+		for _, id := range boundCertificates {
+			if !slices.Contains(union, id) {
+				union = append(union, id)
+			}
+		}
+		if err := setServiceCertificates(ctx, client, service.Id, union); err != nil {
+			return nil, err
+		}
+		boundCertificates = union
+	}
+
+	// Update config - backend uses POST (create) to add a new service config version
+	if newConfig != nil {
+		_, err = callCreate(ctx, client, func() (*ioriver.ServiceConfig, error) {
+			return client.UpdateServiceConfig(service.Id, *newConfig)
+		})
+		if err != nil && !slices.Equal(boundCertificates, previousCertificates) {
+			// the config is unchanged, restore the certificates it was served with
+			if restoreErr := setServiceCertificates(ctx, client, service.Id, previousCertificates); restoreErr != nil {
+				tflog.Warn(ctx, fmt.Sprintf("Failed to restore the certificates %v of service %s: %s", previousCertificates, service.Id, restoreErr))
+			}
+		}
+		if apiErrorStatusCode(err) == http.StatusConflict {
+			// a new version was created after the current version was read
+			current, getErr := callIdempotent(ctx, client, func() (*ioriver.ServiceConfig, error) {
+				return client.GetCurrentServiceConfig(service.Id)
+			})
+			if getErr == nil {
+				return nil, newConfigConflictError(ctx, client, service.Id, parentVersion, current.Version)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Update service fields: name, description, default certificate
 	serviceReq := ioriver.Service{
 		Id:          service.Id,
 		Name:        service.Name,
 		Description: service.Description,
+		Certificate: current.Certificate,
 	}
-	_, err = client.UpdateService(serviceReq)
+	if len(certificates) > 0 {
+		serviceReq.Certificate = certificates[0]
+	}
+	_, err = callIdempotent(ctx, client, func() (*ioriver.Service, error) { return client.UpdateService(serviceReq) })
 	if err != nil {
 		return nil, err
 	}
 
-	return GetServiceWithConfig(client, service.Id)
+	if setList && !slices.Equal(certificates, boundCertificates) {
+		if err := setServiceCertificates(ctx, client, service.Id, certificates); err != nil {
+			return nil, err
+		}
+	}
+
+	return GetServiceWithConfig(ctx, client, service.Id)
 }
 
-func GetServiceWithConfig(client *ioriver.IORiverClient, id string) (*ServiceWithConfig, error) {
-	service, err := client.GetService(id)
+func GetServiceWithConfig(ctx context.Context, client *Client, id string) (*ServiceWithConfig, error) {
+	service, err := callIdempotent(ctx, client, func() (*ioriver.Service, error) {
+		return client.GetService(id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	certificates, listed, err := getServiceCertificates(ctx, client, service.Id, service.Certificate)
 	if err != nil {
 		return nil, err
 	}
 
 	// Fetch the current service config separately
-	serviceConfigResponse, err := client.GetCurrentServiceConfig(id)
+	serviceConfigResponse, err := callIdempotent(ctx, client, func() (*ioriver.ServiceConfig, error) {
+		return client.GetCurrentServiceConfig(id)
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	serviceWithConfig := ServiceWithConfig{
-		Id:           service.Id,
-		Account:      service.Account,
-		Name:         service.Name,
-		Description:  service.Description,
-		Certificates: []string{service.Certificate},
-		ServiceUid:   service.ServiceUid,
-		Cname:        service.Cname,
-		Config:       serviceConfigResponse.ConfigJSON,
+		Id:            service.Id,
+		Account:       service.Account,
+		Name:          service.Name,
+		Description:   service.Description,
+		Certificates:  certificates,
+		ServiceUid:    service.ServiceUid,
+		Cname:         service.Cname,
+		Config:        serviceConfigResponse.ConfigJSON,
+		ConfigVersion: serviceConfigResponse.Version,
+		// set when the API has no certificates list for the service
+		defaultCertificateOnly: !listed,
 	}
 
 	return &serviceWithConfig, nil
 }
 
-func ListServicesWithConfig(client *ioriver.IORiverClient) ([]ServiceWithConfig, error) {
-	services, err := client.ListServices()
+// ListServicesWithConfig returns all the services. Listing the certificates of
+// a service requires an API call per service, so only the default certificate
+// of the services is returned, see loadServicesCertificates. The config of the
+// services is not read, see loadServicesConfig.
+func ListServicesWithConfig(ctx context.Context, client *Client) ([]ServiceWithConfig, error) {
+	services, err := callIdempotent(ctx, client, func() ([]ioriver.Service, error) {
+		return client.ListServices()
+	})
 	if err != nil {
 		return nil, err
 	}
 	servicesWithConfig := make([]ServiceWithConfig, 0, len(services))
 	for _, service := range services {
-		// Map the service to ServiceWithConfig
-		serviceWithConfig := ServiceWithConfig{
-			Id:           service.Id,
-			Account:      service.Account,
-			Name:         service.Name,
-			Description:  service.Description,
-			Certificates: []string{service.Certificate},
-			ServiceUid:   service.ServiceUid,
-			Cname:        service.Cname,
-			Config:       map[string]interface{}{},
+		certificates := []string{}
+		if service.Certificate != "" {
+			certificates = append(certificates, service.Certificate)
 		}
-		servicesWithConfig = append(servicesWithConfig, serviceWithConfig)
+		// Map the service to ServiceWithConfig
+		servicesWithConfig = append(servicesWithConfig, ServiceWithConfig{
+			Id:                     service.Id,
+			Account:                service.Account,
+			Name:                   service.Name,
+			Description:            service.Description,
+			Certificates:           certificates,
+			ServiceUid:             service.ServiceUid,
+			Cname:                  service.Cname,
+			Config:                 map[string]interface{}{},
+			defaultCertificateOnly: true,
+		})
 	}
 	return servicesWithConfig, nil
 }
 
-func DeleteServiceWithConfig(client *ioriver.IORiverClient, id string) error {
-	return client.DeleteService(id)
+func DeleteServiceWithConfig(ctx context.Context, client *Client, id string) error {
+	return callDelete(ctx, client, func() error { return client.DeleteService(id) })
 }
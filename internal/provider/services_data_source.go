@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/exp/slices"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServicesDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &ServicesDataSource{}
+
+// maximal number of services whose config or certificates are loaded in parallel
+const servicesConfigConcurrency = 8
+
+func NewServicesDataSource() datasource.DataSource {
+	return &ServicesDataSource{}
+}
+
+type ServicesDataSource struct {
+	client *Client
+}
+
+type ServicesDataSourceModel struct {
+	NamePrefix    types.String             `tfsdk:"name_prefix"`
+	NameRegex     types.String             `tfsdk:"name_regex"`
+	Certificate   types.String             `tfsdk:"certificate"`
+	IncludeConfig types.Bool               `tfsdk:"include_config"`
+	Ids           []types.String           `tfsdk:"ids"`
+	Services      []ServiceDataSourceModel `tfsdk:"services"`
+}
+
+func (d *ServicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_services"
+}
+
+func (d *ServicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Services data source. Lists the services of the account, optionally filtered by name or certificate.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only return services whose name starts with this prefix",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return services whose name matches this regular expression",
+				Optional:            true,
+			},
+			"certificate": schema.StringAttribute{
+				MarkdownDescription: "Only return services using the certificate with this ID, as their default certificate or as one of their certificates",
+				Optional:            true,
+			},
+			"include_config": schema.BoolAttribute{
+				MarkdownDescription: "Load the current config of each service. Configs are loaded in parallel, but this still requires an API call per service. Defaults to false",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching services",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"services": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching services, ordered by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Service identifier",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Service name",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Service description",
+							Computed:            true,
+						},
+						"cname": schema.StringAttribute{
+							MarkdownDescription: "CNAME for the IO River service",
+							Computed:            true,
+						},
+						"certificate": schema.StringAttribute{
+							MarkdownDescription: "ID of the default certificate of the service",
+							Computed:            true,
+						},
+						"certificates": schema.ListAttribute{
+							MarkdownDescription: "IDs of all the certificates of the service, the default certificate first. Set only when `include_config` is true or the `certificate` filter is set, since it requires an API call per service",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"service_uid": schema.StringAttribute{
+							MarkdownDescription: "Unique identifier for the service",
+							Computed:            true,
+						},
+						"config": schema.SingleNestedAttribute{
+							MarkdownDescription: "Service configuration, set only when `include_config` is true",
+							Computed:            true,
+							Attributes:          computedAttributes(ConfigAttributes()),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ServicesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data ServicesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.NameRegex.IsNull() && !data.NameRegex.IsUnknown() {
+		if _, err := regexp.Compile(data.NameRegex.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+		}
+	}
+}
+
+// Configure data source and retrieve API client
+func (d *ServicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	client := ConfigureDataSourceBase(ctx, req, resp)
+	if client == nil {
+		return
+	}
+	d.client = client
+}
+
+// Read Services data source
+func (d *ServicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ServicesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	services, err := ListServicesWithConfig(ctx, d.client)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, nil, "Client Error", "Unable to list services", err)
+		return
+	}
+
+	if !data.Certificate.IsNull() {
+		// the filter matches any of the certificates of a service, which are only
+		// listed with the default certificate. Configs are loaded after filtering.
+		services, err = loadServicesCertificates(ctx, d.client, services)
+		if err != nil {
+			addAPIErrorDiagnostics(ctx, &resp.Diagnostics, nil, "Client Error", "Unable to read service certificates", err)
+			return
+		}
+	}
+
+	services, err = filterServices(services, data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+		return
+	}
+
+	if data.IncludeConfig.ValueBool() {
+		services, err = loadServicesConfig(ctx, d.client, services)
+		if err != nil {
+			addAPIErrorDiagnostics(ctx, &resp.Diagnostics, nil, "Client Error", "Unable to read service config", err)
+			return
+		}
+	} else {
+		for i := range services {
+			services[i].Config = nil
+		}
+	}
+
+	data.Ids = make([]types.String, 0, len(services))
+	data.Services = make([]ServiceDataSourceModel, 0, len(services))
+	for i := range services {
+		model, err := serviceToDataSourceModel(ctx, &services[i])
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", "Failed to convert IORiver object to data source: "+err.Error())
+			return
+		}
+		data.Ids = append(data.Ids, model.Id)
+		data.Services = append(data.Services, model)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterServices returns the services matching the data source filters, ordered by name
+func filterServices(services []ServiceWithConfig, data ServicesDataSourceModel) ([]ServiceWithConfig, error) {
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		if nameRegex, err = regexp.Compile(data.NameRegex.ValueString()); err != nil {
+			return nil, err
+		}
+	}
+
+	result := []ServiceWithConfig{}
+	for _, service := range services {
+		if !data.NamePrefix.IsNull() && !strings.HasPrefix(service.Name, data.NamePrefix.ValueString()) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(service.Name) {
+			continue
+		}
+		if !data.Certificate.IsNull() && !slices.Contains(service.Certificates, data.Certificate.ValueString()) {
+			continue
+		}
+		result = append(result, service)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Id < result[j].Id
+	})
+	return result, nil
+}
+
+// loadServicesConfig reads the current config of every service, a few services at a time
+func loadServicesConfig(ctx context.Context, client *Client, services []ServiceWithConfig) ([]ServiceWithConfig, error) {
+	result := make([]ServiceWithConfig, len(services))
+	err := forEachConcurrently(len(services), func(i int) error {
+		loaded, err := GetServiceWithConfig(ctx, client, services[i].Id)
+		if err != nil {
+			return fmt.Errorf("service %s: %w", services[i].Id, err)
+		}
+		result[i] = *loaded
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// loadServicesCertificates reads all the certificates of every service, a few
+// services at a time
+func loadServicesCertificates(ctx context.Context, client *Client, services []ServiceWithConfig) ([]ServiceWithConfig, error) {
+	result := slices.Clone(services)
+	err := forEachConcurrently(len(services), func(i int) error {
+		defaultCertificate := ""
+		if len(services[i].Certificates) > 0 {
+			defaultCertificate = services[i].Certificates[0]
+		}
+		certificates, listed, err := getServiceCertificates(ctx, client, services[i].Id, defaultCertificate)
+		if err != nil {
+			return fmt.Errorf("service %s: %w", services[i].Id, err)
+		}
+		result[i].Certificates = certificates
+		result[i].defaultCertificateOnly = !listed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// forEachConcurrently calls fn for 0 to n-1, servicesConfigConcurrency calls at a
+// time, and returns the error of the first failed call
+func forEachConcurrently(n int, fn func(i int) error) error {
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, servicesConfigConcurrency)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ioriver/terraform-provider-ioriver/internal/mockapi"
+)
+
+func TestAccIORiverServicesDataSource_Filter(t *testing.T) {
+	certId := os.Getenv("IORIVER_TEST_CERT_ID")
+	rndName := generateRandomResourceName()
+	resourceName := serviceResourceType + "." + rndName
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckV2(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckServicesDataSourceConfig(rndName, certId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ioriver_services.filtered", "services.#", "1"),
+					resource.TestCheckResourceAttrPair("data.ioriver_services.filtered", "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair("data.ioriver_services.filtered", "services.0.config.uuid", resourceName, "config.uuid"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckServicesDataSourceConfig(resourceName string, certId string) string {
+	return testAccCheckServiceConfigBasic(resourceName, certId) + fmt.Sprintf(`
+
+data "ioriver_services" "filtered" {
+	name_regex     = "^${%[1]s.%[2]s.name}$"
+	certificate    = %[1]s.%[2]s.certificate
+	include_config = true
+}`, serviceResourceType, resourceName)
+}
+
+func TestFilterServices(t *testing.T) {
+	services := []ServiceWithConfig{
+		{Id: "3", Name: "web-b", Certificates: []string{"cert-1"}},
+		{Id: "1", Name: "api", Certificates: []string{"cert-1"}},
+		{Id: "2", Name: "web-a", Certificates: []string{"cert-2", "cert-3"}},
+	}
+
+	cases := []struct {
+		name     string
+		data     ServicesDataSourceModel
+		expected []string
+	}{
+		{"no filters", ServicesDataSourceModel{}, []string{"1", "2", "3"}},
+		{"prefix", ServicesDataSourceModel{NamePrefix: types.StringValue("web-")}, []string{"2", "3"}},
+		{"regex", ServicesDataSourceModel{NameRegex: types.StringValue("^(api|web-b)$")}, []string{"1", "3"}},
+		{"certificate", ServicesDataSourceModel{NamePrefix: types.StringValue("web"), Certificate: types.StringValue("cert-1")}, []string{"3"}},
+		{"additional certificate", ServicesDataSourceModel{Certificate: types.StringValue("cert-3")}, []string{"2"}},
+	}
+
+	for _, c := range cases {
+		result, err := filterServices(services, c.data)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %s", c.name, err)
+		}
+		ids := []string{}
+		for _, service := range result {
+			ids = append(ids, service.Id)
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, ids)
+		}
+	}
+
+	if _, err := filterServices(services, ServicesDataSourceModel{NameRegex: types.StringValue("(")}); err == nil {
+		t.Fatal("expected an error for an invalid regular expression")
+	}
+}
+
+func TestLoadServicesConfig(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	for i := 0; i < 2*servicesConfigConcurrency; i++ {
+		server.AddService(fmt.Sprintf("svc-%02d", i), "cert-id", map[string]interface{}{"domains": []interface{}{}})
+	}
+
+	client := NewClient(server.Endpoint(), "test", "test")
+
+	services, err := ListServicesWithConfig(ctx, client)
+	if err != nil {
+		t.Fatalf("failed to list services: %s", err)
+	}
+	services, err = loadServicesConfig(ctx, client, services)
+	if err != nil {
+		t.Fatalf("failed to load configs: %s", err)
+	}
+
+	data := ServicesDataSourceModel{
+		NamePrefix:    types.StringNull(),
+		NameRegex:     types.StringNull(),
+		Certificate:   types.StringNull(),
+		IncludeConfig: types.BoolValue(true),
+	}
+	for i := range services {
+		if services[i].Config["uuid"] == nil {
+			t.Fatalf("expected config of service %s to be loaded", services[i].Id)
+		}
+		model, err := serviceToDataSourceModel(ctx, &services[i])
+		if err != nil {
+			t.Fatalf("failed to convert service: %s", err)
+		}
+		data.Ids = append(data.Ids, model.Id)
+		data.Services = append(data.Services, model)
+	}
+
+	schemaResp := datasource.SchemaResponse{}
+	(&ServicesDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("failed to set data source state: %v", diags)
+	}
+}
+
+func TestLoadServicesCertificates(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	serviceId := server.AddService("svc", "cert-1", map[string]interface{}{"name": "svc"})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+	_, err := UpdateServiceWithConfig(ctx, client, ServiceWithConfig{
+		Id:           serviceId,
+		Name:         "svc",
+		Certificates: []string{"cert-1", "cert-2"},
+		Config:       map[string]interface{}{"name": "svc"},
+	})
+	if err != nil {
+		t.Fatalf("failed to update service: %s", err)
+	}
+
+	// listing services only returns their default certificate
+	services, err := ListServicesWithConfig(ctx, client)
+	if err != nil {
+		t.Fatalf("failed to list services: %s", err)
+	}
+	model, err := serviceToDataSourceModel(ctx, &services[0])
+	if err != nil {
+		t.Fatalf("failed to convert service: %s", err)
+	}
+	if model.Certificate.ValueString() != "cert-1" || model.Certificates != nil {
+		t.Fatalf("expected only the default certificate, got %+v", model)
+	}
+
+	services, err = loadServicesCertificates(ctx, client, services)
+	if err != nil {
+		t.Fatalf("failed to load certificates: %s", err)
+	}
+	model, err = serviceToDataSourceModel(ctx, &services[0])
+	if err != nil {
+		t.Fatalf("failed to convert service: %s", err)
+	}
+	if len(model.Certificates) != 2 || model.Certificates[1].ValueString() != "cert-2" {
+		t.Fatalf("expected both certificates, got %v", model.Certificates)
+	}
+}
+
+func TestServicesDataSourceRead_AdditionalCertificateWithConfig(t *testing.T) {
+	ctx := context.Background()
+
+	server := mockapi.NewServer()
+	defer server.Close()
+	serviceId := server.AddService("svc", "cert-1", map[string]interface{}{"name": "svc"})
+	server.AddService("other", "cert-1", map[string]interface{}{"name": "other"})
+
+	client := NewClient(server.Endpoint(), "test", "test")
+	_, err := UpdateServiceWithConfig(ctx, client, ServiceWithConfig{
+		Id:           serviceId,
+		Name:         "svc",
+		Certificates: []string{"cert-1", "cert-2"},
+		Config:       map[string]interface{}{"name": "svc"},
+	})
+	if err != nil {
+		t.Fatalf("failed to update service: %s", err)
+	}
+
+	schemaResp := datasource.SchemaResponse{}
+	(&ServicesDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	nullValue := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	config := tfsdk.State{Schema: schemaResp.Schema, Raw: nullValue}
+	diags := config.Set(ctx, &ServicesDataSourceModel{
+		NamePrefix:    types.StringNull(),
+		NameRegex:     types.StringNull(),
+		Certificate:   types.StringValue("cert-2"),
+		IncludeConfig: types.BoolValue(true),
+	})
+	if diags.HasError() {
+		t.Fatalf("failed to set data source config: %v", diags)
+	}
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: nullValue}}
+	(&ServicesDataSource{client: client}).Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	var data ServicesDataSourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("failed to read data source state: %v", diags)
+	}
+	if len(data.Ids) != 1 || data.Ids[0].ValueString() != serviceId {
+		t.Fatalf("expected only service %s to match its additional certificate, got %v", serviceId, data.Ids)
+	}
+	if data.Services[0].Config == nil {
+		t.Fatal("expected the config of the service to be loaded")
+	}
+}
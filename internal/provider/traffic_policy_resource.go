@@ -21,6 +21,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TrafficPolicyResource{}
 var _ resource.ResourceWithImportState = &TrafficPolicyResource{}
+var _ ScopedResource = &TrafficPolicyResource{}
 
 func NewTrafficPolicyResource() resource.Resource {
 	return &TrafficPolicyResource{}
@@ -32,7 +33,7 @@ type TrafficPolicyResourceId struct {
 }
 
 type TrafficPolicyResource struct {
-	client *ioriver.IORiverClient
+	client *Client
 }
 
 type ProviderResourceModel struct {
@@ -275,22 +276,30 @@ func (r *TrafficPolicyResource) ImportState(ctx context.Context, req resource.Im
 
 // ------- Implement base Resource API ---------
 
-func (TrafficPolicyResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
-	return client.CreateTrafficPolicy(newObj.(ioriver.TrafficPolicy))
+func (TrafficPolicyResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
+	return callCreate(ctx, client, func() (*ioriver.TrafficPolicy, error) {
+		return client.CreateTrafficPolicy(newObj.(ioriver.TrafficPolicy))
+	})
 }
 
-func (TrafficPolicyResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
+func (TrafficPolicyResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
 	resourceId := id.(TrafficPolicyResourceId)
-	return client.GetTrafficPolicy(resourceId.serviceId, resourceId.trafficPolicyId)
+	return callIdempotent(ctx, client, func() (*ioriver.TrafficPolicy, error) {
+		return client.GetTrafficPolicy(resourceId.serviceId, resourceId.trafficPolicyId)
+	})
 }
 
-func (TrafficPolicyResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
-	return client.UpdateTrafficPolicy(obj.(ioriver.TrafficPolicy))
+func (TrafficPolicyResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
+	return callIdempotent(ctx, client, func() (*ioriver.TrafficPolicy, error) {
+		return client.UpdateTrafficPolicy(obj.(ioriver.TrafficPolicy))
+	})
 }
 
-func (TrafficPolicyResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
+func (TrafficPolicyResource) delete(ctx context.Context, client *Client, id interface{}) error {
 	resourceId := id.(TrafficPolicyResourceId)
-	return client.DeleteTrafficPolicy(resourceId.serviceId, resourceId.trafficPolicyId)
+	return callDelete(ctx, client, func() error {
+		return client.DeleteTrafficPolicy(resourceId.serviceId, resourceId.trafficPolicyId)
+	})
 }
 
 func (TrafficPolicyResource) getId(data interface{}) interface{} {
@@ -300,6 +309,11 @@ func (TrafficPolicyResource) getId(data interface{}) interface{} {
 	return TrafficPolicyResourceId{trafficPolicyId, serviceId}
 }
 
+func (TrafficPolicyResource) lockScope(data interface{}) string {
+	d := data.(TrafficPolicyResourceModel)
+	return ServiceLockScope(d.Service.ValueString())
+}
+
 // Convert TrafficPolicy resource to TrafficPolicy API object
 func (TrafficPolicyResource) resourceToObj(ctx context.Context, data interface{}) (interface{}, error) {
 	d := data.(TrafficPolicyResourceModel)
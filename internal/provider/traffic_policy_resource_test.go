@@ -28,17 +28,17 @@ type TestedTrafficPolicy struct {
 	TestedObj[ioriver.TrafficPolicy]
 }
 
-func (TestedTrafficPolicy) Get(client *ioriver.IORiverClient, id string) (*ioriver.TrafficPolicy, error) {
+func (TestedTrafficPolicy) Get(client *Client, id string) (*ioriver.TrafficPolicy, error) {
 	serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
 	return client.GetTrafficPolicy(serviceId, id)
 }
 
-func (TestedTrafficPolicy) List(client *ioriver.IORiverClient) ([]ioriver.TrafficPolicy, error) {
+func (TestedTrafficPolicy) List(client *Client) ([]ioriver.TrafficPolicy, error) {
 	serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
 	return client.ListTrafficPolicies(serviceId)
 }
 
-func (TestedTrafficPolicy) Delete(client *ioriver.IORiverClient, object ioriver.TrafficPolicy, excludeIds []string) error {
+func (TestedTrafficPolicy) Delete(client *Client, object ioriver.TrafficPolicy, excludeIds []string) error {
 	idx := slices.IndexFunc(excludeIds, func(id string) bool { return id == object.Id })
 	if idx < 0 {
 		serviceId := os.Getenv("IORIVER_TEST_SERVICE_ID")
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	ioriver "github.com/ioriver/ioriver-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -121,19 +120,19 @@ func (r *UrlSigningKeyResource) ImportState(ctx context.Context, req resource.Im
 
 // ------- Implement base Resource API ---------
 
-func (UrlSigningKeyResource) create(ctx context.Context, client *ioriver.IORiverClient, newObj interface{}) (interface{}, error) {
+func (UrlSigningKeyResource) create(ctx context.Context, client *Client, newObj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (UrlSigningKeyResource) read(ctx context.Context, client *ioriver.IORiverClient, id interface{}) (interface{}, error) {
+func (UrlSigningKeyResource) read(ctx context.Context, client *Client, id interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (UrlSigningKeyResource) update(ctx context.Context, client *ioriver.IORiverClient, obj interface{}) (interface{}, error) {
+func (UrlSigningKeyResource) update(ctx context.Context, client *Client, obj interface{}) (interface{}, error) {
 	return nil, nil
 }
 
-func (UrlSigningKeyResource) delete(ctx context.Context, client *ioriver.IORiverClient, id interface{}) error {
+func (UrlSigningKeyResource) delete(ctx context.Context, client *Client, id interface{}) error {
 	return nil
 }
 
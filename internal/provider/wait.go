@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultPollInterval = 10 * time.Second
+
+	// default timeouts of operations which wait for the backend to complete an async task
+	defaultCreateTimeout = 60 * time.Minute
+	defaultUpdateTimeout = 60 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
+
+// WaitError is returned when an object did not reach the expected state before
+// the operation timed out or was cancelled, or when it reached a failed state.
+// The object itself exists on the backend.
+type WaitError struct {
+	Description string
+	// Cause is the context error or the *StateError which stopped the wait
+	Cause error
+	// LastErr is the error returned by the last check, if it failed
+	LastErr error
+}
+
+func (e *WaitError) Error() string {
+	var stateErr *StateError
+	if errors.As(e.Cause, &stateErr) {
+		return fmt.Sprintf("failed while waiting for %s, %s", e.Description, stateErr)
+	}
+
+	reason := "timeout"
+	if errors.Is(e.Cause, context.Canceled) {
+		reason = "cancelled"
+	}
+	msg := fmt.Sprintf("%s while waiting for %s", reason, e.Description)
+	if e.LastErr != nil {
+		msg += ", last error: " + e.LastErr.Error()
+	}
+	return msg
+}
+
+func (e *WaitError) Unwrap() []error {
+	return []error{e.Cause, e.LastErr}
+}
+
+// StateError is returned by a wait check when the object reached a failed state,
+// which ends the wait
+type StateError struct {
+	Status string
+	// Reason reported by the backend, if any
+	Reason string
+}
+
+func (e *StateError) Error() string {
+	if e.Reason == "" {
+		return "status " + e.Status
+	}
+	return fmt.Sprintf("status %s: %s", e.Status, e.Reason)
+}
+
+// waitUntil calls check every poll interval until it reports done or the context
+// is done. A failed check doesn't stop the wait, since the object may not be
+// fully available right after it was created, unless it returns a *StateError.
+func waitUntil(ctx context.Context, client *Client, description string, check func() (bool, error)) error {
+	interval := client.pollInterval
+
+	for {
+		done, err := check()
+		if err == nil && done {
+			return nil
+		}
+		var stateErr *StateError
+		if errors.As(err, &stateErr) {
+			return &WaitError{Description: description, Cause: err}
+		}
+
+		select {
+		case <-ctx.Done():
+			return &WaitError{Description: description, Cause: ctx.Err(), LastErr: err}
+		case <-time.After(interval):
+		}
+	}
+}
+
+func isWaitError(err error) bool {
+	var waitErr *WaitError
+	return errors.As(err, &waitErr)
+}
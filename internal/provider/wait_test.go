@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWaitUntil_Done(t *testing.T) {
+	client := NewClient("", "", "test")
+	client.pollInterval = time.Millisecond
+
+	calls := 0
+	err := waitUntil(context.Background(), client, "object", func() (bool, error) {
+		calls++
+		if calls == 1 {
+			return false, errors.New("not found yet")
+		}
+		return calls == 3, nil
+	})
+	if err != nil {
+		t.Fatalf("expected wait to succeed, got %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 checks, got %d", calls)
+	}
+}
+
+func TestWaitUntil_Timeout(t *testing.T) {
+	client := NewClient("", "", "test")
+	client.pollInterval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	checkErr := errors.New("still failing")
+	err := waitUntil(ctx, client, "object to become active", func() (bool, error) {
+		return false, checkErr
+	})
+	if !isWaitError(err) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a wait timeout error, got %v", err)
+	}
+	if !errors.Is(err, checkErr) {
+		t.Fatalf("expected timeout error to wrap the last check error, got %v", err)
+	}
+}
+
+func TestWaitUntil_Cancelled(t *testing.T) {
+	client := NewClient("", "", "test")
+	client.pollInterval = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	done := make(chan error)
+	go func() {
+		done <- waitUntil(ctx, client, "object to become active", func() (bool, error) { return false, nil })
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected a cancelled wait error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected wait to stop when the context is cancelled")
+	}
+}
+
+func TestWaitUntil_StateError(t *testing.T) {
+	client := NewClient("", "", "test")
+	client.pollInterval = time.Hour
+
+	calls := 0
+	err := waitUntil(context.Background(), client, "object to become active", func() (bool, error) {
+		calls++
+		return false, &StateError{Status: "FAILED", Reason: "quota exceeded"}
+	})
+	var stateErr *StateError
+	if !isWaitError(err) || !errors.As(err, &stateErr) {
+		t.Fatalf("expected a wait error caused by the failed state, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the wait to stop after the first check, got %d checks", calls)
+	}
+	if err.Error() != "failed while waiting for object to become active, status FAILED: quota exceeded" {
+		t.Fatalf("unexpected error message: %s", err)
+	}
+}
+
+func TestGetPollInterval_Default(t *testing.T) {
+	if interval := NewClient("", "", "test").pollInterval; interval != DefaultPollInterval {
+		t.Fatalf("expected default poll interval %s, got %s", DefaultPollInterval, interval)
+	}
+}
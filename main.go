@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/ioriver/terraform-provider-ioriver/internal/provider"
@@ -29,6 +30,13 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(context.Background(), os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -45,3 +53,34 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// runExport writes the existing services as Terraform configuration, e.g.
+//
+//	terraform-provider-ioriver export -name-prefix prod- -out services.tf
+func runExport(ctx context.Context, args []string) error {
+	opts := provider.ExportOptions{Version: version}
+	var out string
+
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	flags.StringVar(&opts.Endpoint, "endpoint", "", "IO River API endpoint, defaults to $"+provider.APIEndpointEnvVar)
+	flags.StringVar(&opts.Token, "token", "", "IO River API token, defaults to $"+provider.APITokenEvnVar)
+	flags.StringVar(&opts.NamePrefix, "name-prefix", "", "only export services whose name starts with this prefix")
+	flags.StringVar(&opts.NameRegex, "name-regex", "", "only export services whose name matches this regular expression")
+	flags.StringVar(&out, "out", "", "file to write the configuration to, defaults to stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if out == "" {
+		return provider.Export(ctx, opts, os.Stdout)
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := provider.Export(ctx, opts, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}